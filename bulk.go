@@ -0,0 +1,362 @@
+package yamgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultBulkChunkSize is the number of operations sent to the server in a
+// single bulk write command.
+const DefaultBulkChunkSize = 1000
+
+type (
+	// Bulk queues write operations and executes them as bulk writes.
+	Bulk struct {
+		model     *Model
+		writes    []mongo.WriteModel
+		ordered   bool
+		chunkSize int
+		err       error
+		// versioned lists the queued replacements whose caller document gets
+		// the incremented version once the replace succeeded.
+		versioned []queuedVersion
+	}
+
+	queuedVersion struct {
+		index int
+		doc   interface{}
+	}
+
+	// WriteFailure describes a single write rejected by the server. Index is
+	// the position of the operation in the batch.
+	WriteFailure struct {
+		Index   int
+		Code    int
+		Message string
+	}
+
+	// WriteStatus is the outcome of a single queued bulk operation.
+	WriteStatus int
+
+	BulkResult struct {
+		InsertedCount int64
+		MatchedCount  int64
+		ModifiedCount int64
+		DeletedCount  int64
+		UpsertedCount int64
+		// UpsertedIDs maps the index of each upserting operation to the _id
+		// of the document it created.
+		UpsertedIDs map[int]interface{}
+		// Executed is the number of operations the server processed. With
+		// ordered execution it stops at the first failed operation.
+		Executed int
+		Failures []WriteFailure
+		// Statuses holds the outcome of each queued operation, by index.
+		Statuses []WriteStatus
+	}
+
+	// BulkWriteError is returned by Execute when some operations failed.
+	BulkWriteError struct {
+		Failures []WriteFailure
+	}
+)
+
+const (
+	// WriteNotExecuted operations were not sent, or were skipped by the
+	// server after an earlier ordered failure.
+	WriteNotExecuted WriteStatus = iota
+	WriteSucceeded
+	WriteFailed
+	// WriteUnknown operations were sent in a chunk that failed without
+	// reporting the outcome of each write, e.g. on a network error.
+	WriteUnknown
+)
+
+func (e *BulkWriteError) Error() string {
+	if len(e.Failures) == 0 {
+		return "bulk write failed"
+	}
+	return fmt.Sprintf("bulk write failed: %d operations failed, first error at index %d: %s",
+		len(e.Failures), e.Failures[0].Index, e.Failures[0].Message)
+}
+
+// IsDuplicateKey reports whether the write failed on a unique index.
+func (f WriteFailure) IsDuplicateKey() bool {
+	return f.Code == 11000 || f.Code == 11001 || f.Code == 12582
+}
+
+// IsValidation reports whether the document failed the collection validator.
+func (f WriteFailure) IsValidation() bool {
+	return f.Code == 121
+}
+
+// FailedIndexes returns the indexes of the operations that failed.
+func (r BulkResult) FailedIndexes() []int {
+	indexes := make([]int, 0, len(r.Failures))
+	for _, failure := range r.Failures {
+		indexes = append(indexes, failure.Index)
+	}
+	return indexes
+}
+
+// Bulk returns an empty ordered bulk builder for the model.
+func (mf *Model) Bulk() *Bulk {
+	return &Bulk{model: mf, ordered: true, chunkSize: DefaultBulkChunkSize}
+}
+
+// Ordered sets whether the execution stops at the first failed operation.
+func (b *Bulk) Ordered(ordered bool) *Bulk {
+	b.ordered = ordered
+	return b
+}
+
+// ChunkSize sets how many operations are sent in each bulk write command.
+func (b *Bulk) ChunkSize(size int) *Bulk {
+	if size > 0 {
+		b.chunkSize = size
+	}
+	return b
+}
+
+func (b *Bulk) Insert(docs ...interface{}) *Bulk {
+	for _, doc := range docs {
+		doc = b.prepare(doc, b.model.beforeInsert, true)
+		b.writes = append(b.writes, mongo.NewInsertOneModel().SetDocument(doc))
+	}
+	return b
+}
+
+func (b *Bulk) UpdateOne(filter bson.M, update interface{}) *Bulk {
+	b.writes = append(b.writes, mongo.NewUpdateOneModel().SetFilter(b.model.scope(filter)).SetUpdate(b.model.prepareUpdate(update)))
+	return b
+}
+
+func (b *Bulk) UpdateMany(filter bson.M, update interface{}) *Bulk {
+	b.writes = append(b.writes, mongo.NewUpdateManyModel().SetFilter(b.model.scope(filter)).SetUpdate(b.model.prepareUpdate(update)))
+	return b
+}
+
+func (b *Bulk) UpsertOne(filter bson.M, update interface{}) *Bulk {
+	b.writes = append(b.writes, mongo.NewUpdateOneModel().SetFilter(b.model.scope(filter)).SetUpdate(b.model.prepareUpdate(update)).SetUpsert(true))
+	return b
+}
+
+func (b *Bulk) ReplaceOne(filter bson.M, replacement interface{}) *Bulk {
+	replacement = b.prepare(replacement, b.model.beforeUpdate, false)
+	filter, replacement = b.versionReplace(filter, replacement)
+	b.writes = append(b.writes, mongo.NewReplaceOneModel().SetFilter(b.model.scope(filter)).SetReplacement(replacement))
+	return b
+}
+
+func (b *Bulk) UpsertReplace(filter bson.M, replacement interface{}) *Bulk {
+	replacement = b.prepare(replacement, b.model.beforeUpdate, true)
+	filter, replacement = b.versionReplace(filter, replacement)
+	b.writes = append(b.writes, mongo.NewReplaceOneModel().SetFilter(b.model.scope(filter)).SetReplacement(replacement).SetUpsert(true))
+	return b
+}
+
+// It runs the hook of a queued document, stamps it, with the creation time
+// when created is set, and validates it. The first failure is recorded and
+// makes Execute fail before sending any operation.
+func (b *Bulk) prepare(doc interface{}, hook func(interface{}) (interface{}, error), created bool) interface{} {
+	if b.err != nil {
+		return doc
+	}
+
+	doc, err := hook(doc)
+	if err == nil {
+		doc = b.model.stampDocument(doc, created)
+		err = b.model.validate(doc)
+	}
+	if err != nil {
+		b.err = fmt.Errorf("operation %d: %w", len(b.writes), err)
+	}
+
+	return doc
+}
+
+// It matches a queued replacement on its version and increments it. A stale
+// version shows up as an unmatched replace or a duplicate key failure.
+// The version of a copy is incremented, so the caller's document keeps its
+// version until the replace succeeded.
+func (b *Bulk) versionReplace(filter bson.M, replacement interface{}) (bson.M, interface{}) {
+	version, queued, _, versioned := b.model.bumpVersion(shallowCopy(replacement))
+	if !versioned {
+		return filter, replacement
+	}
+	b.versioned = append(b.versioned, queuedVersion{index: len(b.writes), doc: replacement})
+	return b.model.versionFilter(filter, version), queued
+}
+
+// It copies a map or the struct a pointer refers to, so the copy can be
+// changed without touching doc.
+func shallowCopy(doc interface{}) interface{} {
+	if m, ok := doc.(bson.M); ok {
+		copied := make(bson.M, len(m))
+		for key, value := range m {
+			copied[key] = value
+		}
+		return copied
+	}
+
+	val := reflect.ValueOf(doc)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return doc
+	}
+
+	copied := reflect.New(val.Elem().Type())
+	copied.Elem().Set(val.Elem())
+
+	return copied.Interface()
+}
+
+// DeleteOne queues a delete, or a soft delete on soft deleting models.
+func (b *Bulk) DeleteOne(filter bson.M) *Bulk {
+	if b.model.deletedField != "" {
+		b.writes = append(b.writes, mongo.NewUpdateOneModel().SetFilter(b.softDeleteFilter(filter)).SetUpdate(b.model.prepareUpdate(b.model.softDeleteUpdate())))
+		return b
+	}
+	b.writes = append(b.writes, mongo.NewDeleteOneModel().SetFilter(filter))
+	return b
+}
+
+// DeleteMany queues a delete, or a soft delete on soft deleting models.
+func (b *Bulk) DeleteMany(filter bson.M) *Bulk {
+	if b.model.deletedField != "" {
+		b.writes = append(b.writes, mongo.NewUpdateManyModel().SetFilter(b.softDeleteFilter(filter)).SetUpdate(b.model.prepareUpdate(b.model.softDeleteUpdate())))
+		return b
+	}
+	b.writes = append(b.writes, mongo.NewDeleteManyModel().SetFilter(filter))
+	return b
+}
+
+// It restricts a soft delete to the documents that are not deleted yet.
+func (b *Bulk) softDeleteFilter(filter bson.M) bson.M {
+	model := *b.model
+	model.deletedScope = excludeDeleted
+	return model.scope(filter)
+}
+
+// Len returns the number of queued operations.
+func (b *Bulk) Len() int {
+	return len(b.writes)
+}
+
+// Execute sends the queued operations in chunks. When some writes fail the
+// result is still returned, together with a *BulkWriteError. Nothing is sent
+// when a queued document failed its hook or validation. The documents of
+// versioned replacements get their new version only when the replace
+// succeeded.
+func (b *Bulk) Execute() (BulkResult, error) {
+
+	result := BulkResult{UpsertedIDs: map[int]interface{}{}, Statuses: make([]WriteStatus, len(b.writes))}
+
+	if len(b.writes) == 0 {
+		return result, errors.New("bulk has no operations")
+	}
+
+	if b.err != nil {
+		return result, b.err
+	}
+
+	op := &Operation{Kind: OperationBulkWrite, Writes: b.writes, Options: b.ordered}
+
+	err := b.model.run(op, func(model *Model, op *Operation) (err error) {
+		ordered, _ := op.Options.(bool)
+		op.Result, err = b.execute(model, op.Writes, ordered)
+		return err
+	})
+
+	if res, ok := op.Result.(BulkResult); ok {
+		result = res
+	}
+
+	for _, queued := range b.versioned {
+		if queued.index < len(result.Statuses) && result.Statuses[queued.index] == WriteSucceeded {
+			b.model.bumpVersion(queued.doc)
+		}
+	}
+	b.versioned = nil
+
+	return result, err
+}
+
+func (b *Bulk) execute(model *Model, writes []mongo.WriteModel, ordered bool) (BulkResult, error) {
+
+	result := BulkResult{UpsertedIDs: map[int]interface{}{}, Statuses: make([]WriteStatus, len(writes))}
+
+	for offset := 0; offset < len(writes); offset += b.chunkSize {
+		end := offset + b.chunkSize
+		if end > len(writes) {
+			end = len(writes)
+		}
+
+		res, err := model.executeBulkChunk(writes[offset:end], ordered)
+
+		if res != nil {
+			result.InsertedCount += res.InsertedCount
+			result.MatchedCount += res.MatchedCount
+			result.ModifiedCount += res.ModifiedCount
+			result.DeletedCount += res.DeletedCount
+			result.UpsertedCount += res.UpsertedCount
+			for index, id := range res.UpsertedIDs {
+				result.UpsertedIDs[offset+int(index)] = id
+			}
+		}
+
+		var bulkErr mongo.BulkWriteException
+		if err != nil && (!errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil) {
+			result.Executed = end
+			setStatus(result.Statuses[offset:end], WriteUnknown)
+			return result, err
+		}
+
+		// Ordered chunks stop at their only failed write.
+		processed := end
+		if ordered && len(bulkErr.WriteErrors) > 0 {
+			processed = offset + bulkErr.WriteErrors[0].Index + 1
+		}
+
+		result.Executed = processed
+		setStatus(result.Statuses[offset:processed], WriteSucceeded)
+
+		for _, writeErr := range bulkErr.WriteErrors {
+			result.Statuses[offset+writeErr.Index] = WriteFailed
+			result.Failures = append(result.Failures, WriteFailure{
+				Index:   offset + writeErr.Index,
+				Code:    writeErr.Code,
+				Message: writeErr.Message,
+			})
+		}
+
+		if ordered && len(bulkErr.WriteErrors) > 0 {
+			break
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		return result, &BulkWriteError{Failures: result.Failures}
+	}
+
+	return result, nil
+}
+
+func setStatus(statuses []WriteStatus, status WriteStatus) {
+	for i := range statuses {
+		statuses[i] = status
+	}
+}
+
+func (mf *Model) executeBulkChunk(writes []mongo.WriteModel, ordered bool) (*mongo.BulkWriteResult, error) {
+
+	ctx, cancel := mf.newContext(OperationBulkWrite)
+
+	defer cancel()
+
+	return mf.col.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(ordered))
+}
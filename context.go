@@ -0,0 +1,42 @@
+package yamgo
+
+import (
+	"context"
+)
+
+// WithContext returns a copy of the model whose operations run under ctx, so
+// request cancellation, deadlines and tracing values reach the driver.
+func (mf *Model) WithContext(ctx context.Context) *Model {
+	model := *mf
+	model.ctx = ctx
+	return &model
+}
+
+// It derives the context of a single operation from the model context. The
+// configured timeouts only apply when the caller did not set a deadline, while
+// a WithTimeout override applies together with it, so the earlier one wins.
+func (mf *Model) newContext(kind OperationKind) (context.Context, context.CancelFunc) {
+	ctx := withOperationState(mf.context())
+
+	if _, ok := ctx.Deadline(); ok {
+		if mf.timeout > 0 {
+			return context.WithTimeout(ctx, mf.timeout)
+		}
+		return context.WithCancel(ctx)
+	}
+
+	timeout := mf.operationTimeout(kind)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (mf *Model) context() context.Context {
+	if mf.ctx == nil {
+		return context.Background()
+	}
+
+	return mf.ctx
+}
@@ -1,18 +1,41 @@
 package yamgo
 
 import (
-	"context"
-	"time"
+	"errors"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (mf *Model) CountDocuments(filter bson.M) (int, error) {
+// CountDocuments counts the documents matching filter. Limit, skip, hint and
+// collation are taken from the options; a limit caps the count, so the server
+// can stop scanning once it is reached.
+func (mf *Model) CountDocuments(filter bson.M, opts ...*options.CountOptions) (int, error) {
+	op := &Operation{Kind: OperationCount, Filter: filter, Options: opts}
 
-	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.CountOptions)
+		op.Result, err = model.countDocuments(op.Filter, opts...)
+		return err
+	})
+
+	count, _ := op.Result.(int)
+
+	return count, err
+}
+
+func (mf *Model) countDocuments(filter bson.M, opts ...*options.CountOptions) (int, error) {
+
+	ctx, cancel := mf.newContext(OperationCount)
 	defer cancel()
 
-	count, err := mf.col.CountDocuments(ctx, filter)
+	option := options.MergeCountOptions(opts...)
+	if option.MaxTime == nil {
+		option.MaxTime = maxTime(ctx)
+	}
+
+	count, err := mf.col.CountDocuments(ctx, mf.scope(filter), option)
 
 	if err != nil {
 		return 0, err
@@ -20,3 +43,103 @@ func (mf *Model) CountDocuments(filter bson.M) (int, error) {
 
 	return int(count), nil
 }
+
+// EstimatedDocumentCount returns the count of the collection from its
+// metadata, without scanning it. Soft deleted documents are counted too.
+func (mf *Model) EstimatedDocumentCount() (int, error) {
+	op := &Operation{Kind: OperationCount}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		op.Result, err = model.estimatedDocumentCount()
+		return err
+	})
+
+	count, _ := op.Result.(int)
+
+	return count, err
+}
+
+func (mf *Model) estimatedDocumentCount() (int, error) {
+
+	ctx, cancel := mf.newContext(OperationCount)
+	defer cancel()
+
+	count, err := mf.col.EstimatedDocumentCount(ctx, &options.EstimatedDocumentCountOptions{MaxTime: maxTime(ctx)})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// Exists reports whether at least one document matches filter, fetching only
+// the _id of the first match.
+func (mf *Model) Exists(filter bson.M) (bool, error) {
+	op := &Operation{Kind: OperationExists, Filter: filter}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		op.Result, err = model.exists(op.Filter)
+		return err
+	})
+
+	exists, _ := op.Result.(bool)
+
+	return exists, err
+}
+
+func (mf *Model) exists(filter bson.M) (bool, error) {
+
+	ctx, cancel := mf.newContext(OperationExists)
+	defer cancel()
+
+	option := &options.FindOneOptions{Projection: bson.M{"_id": 1}, MaxTime: maxTime(ctx)}
+
+	err := mf.col.FindOne(ctx, mf.scope(filter), option).Err()
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Distinct decodes the distinct values of field among the documents matching
+// filter into results, which must be a pointer to a slice.
+func (mf *Model) Distinct(field string, filter bson.M, results interface{}, opts ...*options.DistinctOptions) error {
+	op := &Operation{Kind: OperationDistinct, Filter: filter, Options: opts, Result: results}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		opts, _ := op.Options.([]*options.DistinctOptions)
+		return model.distinct(field, op.Filter, op.Result, opts...)
+	})
+}
+
+func (mf *Model) distinct(field string, filter bson.M, results interface{}, opts ...*options.DistinctOptions) error {
+
+	ctx, cancel := mf.newContext(OperationDistinct)
+	defer cancel()
+
+	option := options.MergeDistinctOptions(opts...)
+	if option.MaxTime == nil {
+		option.MaxTime = maxTime(ctx)
+	}
+
+	values, err := mf.col.Distinct(ctx, field, mf.scope(filter), option)
+
+	if err != nil {
+		return err
+	}
+
+	valueType, data, err := bson.MarshalValue(values)
+
+	if err != nil {
+		return err
+	}
+
+	return bson.RawValue{Type: valueType, Value: data}.Unmarshal(results)
+}
@@ -0,0 +1,92 @@
+package yamgo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeleteOne deletes the first document matching filter. On soft deleting
+// models the document is marked as deleted instead.
+func (mf *Model) DeleteOne(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	op := &Operation{Kind: OperationDeleteOne, Filter: filter, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.DeleteOptions)
+		op.Result, err = model.deleteOne(op.Filter, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.DeleteResult)
+
+	return res, err
+}
+
+func (mf *Model) deleteOne(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+
+	if mf.deletedField != "" {
+		return mf.softDelete(filter, false, opts)
+	}
+
+	ctx, cancel := mf.newContext(OperationDeleteOne)
+
+	defer cancel()
+
+	res, err := mf.col.DeleteOne(ctx, filter, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteMany deletes every document matching filter. On soft deleting models
+// the documents are marked as deleted instead.
+func (mf *Model) DeleteMany(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	op := &Operation{Kind: OperationDeleteMany, Filter: filter, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.DeleteOptions)
+		op.Result, err = model.deleteMany(op.Filter, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.DeleteResult)
+
+	return res, err
+}
+
+func (mf *Model) deleteMany(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+
+	if mf.deletedField != "" {
+		return mf.softDelete(filter, true, opts)
+	}
+
+	ctx, cancel := mf.newContext(OperationDeleteMany)
+
+	defer cancel()
+
+	res, err := mf.col.DeleteMany(ctx, filter, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (mf *Model) DeleteByID(id string, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mf.DeleteByObjectID(objectID, opts...)
+}
+
+func (mf *Model) DeleteByObjectID(objectID primitive.ObjectID, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return mf.DeleteOne(bson.M{"_id": objectID}, opts...)
+}
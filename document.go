@@ -0,0 +1,95 @@
+package yamgo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// It returns the bson key of a struct field and whether the field is inlined,
+// following the rules of the bson struct codec.
+func bsonKey(field reflect.StructField) (key string, inline bool) {
+	tag, ok := field.Tag.Lookup("bson")
+	if !ok {
+		return strings.ToLower(field.Name), false
+	}
+
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+
+	if parts[0] == "" {
+		return strings.ToLower(field.Name), inline
+	}
+
+	return parts[0], inline
+}
+
+// It walks a pointer or a struct value down to the struct itself.
+func documentStruct(doc interface{}) (reflect.Value, error) {
+	val := reflect.ValueOf(doc)
+
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}, errors.New("document can't be nil")
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("document must be a struct")
+	}
+
+	return val, nil
+}
+
+// It finds the struct field stored under the given bson key, looking into
+// inlined structs as the bson codec does.
+func fieldByBSONKey(val reflect.Value, key string) (reflect.Value, bool) {
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("bson") == "-" {
+			continue
+		}
+
+		name, inline := bsonKey(field)
+		if inline && field.Type.Kind() == reflect.Struct {
+			if found, ok := fieldByBSONKey(val.Field(i), key); ok {
+				return found, true
+			}
+			continue
+		}
+
+		if name == key {
+			return val.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// It reads the _id of a decoded document of any type.
+func documentID(doc interface{}) (interface{}, error) {
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := bson.Raw(data).LookupErr("_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var id interface{}
+	err = value.Unmarshal(&id)
+
+	return id, err
+}
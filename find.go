@@ -1,12 +1,10 @@
 package yamgo
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
-	"time"
 
 	P "github.com/gobeam/mongo-go-pagination"
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,15 +18,34 @@ type PopulateOptions struct {
 	LocalField string
 	As         string
 	Projection []string
+	// SoftDeleteField excludes the populated documents where it is set. It
+	// defaults to the field given to WithSoftDeletedLookup for Collection.
+	SoftDeleteField string
+	// WithDeleted also populates the soft deleted documents.
+	WithDeleted bool
 }
 
-func (mf *Model) FindOne(filter bson.M, result interface{}) (err error) {
+// FindByIDsResult reports the ids that FindByIDs could not resolve.
+type FindByIDsResult struct {
+	Missing   []string
+	Malformed []string
+}
+
+func (mf *Model) FindOne(filter bson.M, result interface{}) error {
+	op := &Operation{Kind: OperationFindOne, Filter: filter, Result: result}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		return model.findOne(op.Filter, op.Result)
+	})
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), MediumTimeout*time.Second)
+func (mf *Model) findOne(filter bson.M, result interface{}) (err error) {
+
+	ctx, cancel := mf.newContext(OperationFindOne)
 
 	defer cancel()
 
-	res := mf.col.FindOne(ctx, filter)
+	res := mf.col.FindOne(ctx, mf.scope(filter), &options.FindOneOptions{MaxTime: maxTime(ctx)})
 
 	if res.Err() != nil {
 		return res.Err()
@@ -40,7 +57,7 @@ func (mf *Model) FindOne(filter bson.M, result interface{}) (err error) {
 		return err
 	}
 
-	return nil
+	return mf.afterFind(result)
 }
 
 func (mf *Model) FindByID(id string, result interface{}) (err error) {
@@ -62,11 +79,95 @@ func (mf *Model) FindByObjectID(objectID primitive.ObjectID, result interface{})
 	return mf.FindOne(bson.M{"_id": objectID}, result)
 }
 
+// FindByIDs loads the documents with the given hex ids with a single query
+// and stores them into results in the order of ids. Duplicated ids are
+// returned once; missing and malformed ids are reported.
+func (mf *Model) FindByIDs(ids []string, results interface{}) (FindByIDsResult, error) {
+
+	var report FindByIDsResult
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			report.Malformed = append(report.Malformed, id)
+			continue
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	found, err := mf.FindByObjectIDs(objectIDs, results)
+	found.Malformed = report.Malformed
+
+	return found, err
+}
+
+// FindByObjectIDs is like FindByIDs for ObjectIDs.
+func (mf *Model) FindByObjectIDs(ids []primitive.ObjectID, results interface{}) (FindByIDsResult, error) {
+
+	var report FindByIDsResult
+
+	resultsPtr := reflect.ValueOf(results)
+	if resultsPtr.Kind() != reflect.Ptr || resultsPtr.Elem().Kind() != reflect.Slice {
+		return report, errors.New("results must be a pointer to a slice")
+	}
+
+	unique := make([]primitive.ObjectID, 0, len(ids))
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	err := mf.Find(bson.M{"_id": bson.M{"$in": unique}}, results)
+
+	if err != nil {
+		return report, err
+	}
+
+	resultsVal := resultsPtr.Elem()
+	byID := make(map[primitive.ObjectID]reflect.Value, resultsVal.Len())
+
+	for i := 0; i < resultsVal.Len(); i++ {
+		id, err := documentID(resultsVal.Index(i).Interface())
+		if err != nil {
+			return report, err
+		}
+		if objectID, ok := id.(primitive.ObjectID); ok {
+			byID[objectID] = resultsVal.Index(i)
+		}
+	}
+
+	ordered := reflect.MakeSlice(resultsVal.Type(), 0, len(unique))
+	for _, id := range unique {
+		doc, ok := byID[id]
+		if !ok {
+			report.Missing = append(report.Missing, id.Hex())
+			continue
+		}
+		ordered = reflect.Append(ordered, doc)
+	}
+
+	resultsVal.Set(ordered)
+
+	return report, nil
+}
+
 func (mf *Model) Find(filter bson.M, results interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+	op := &Operation{Kind: OperationFind, Filter: filter, Result: results}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		return model.find(op.Filter, op.Result)
+	})
+}
+
+func (mf *Model) find(filter bson.M, results interface{}) error {
+	ctx, cancel := mf.newContext(OperationFind)
 	defer cancel()
 
-	cur, err := mf.col.Find(ctx, filter)
+	cur, err := mf.col.Find(ctx, mf.scope(filter), &options.FindOptions{MaxTime: maxTime(ctx)})
 	if err != nil {
 		return err
 	}
@@ -75,7 +176,7 @@ func (mf *Model) Find(filter bson.M, results interface{}) error {
 		return err
 	}
 
-	return nil
+	return mf.afterFind(results)
 }
 
 func (mf *Model) executeCursorQuery(query []bson.M, sort bson.D, limit int64, collation *options.Collation, hint interface{}, projection string, lookups []PopulateOptions, results interface{}) error {
@@ -101,15 +202,37 @@ func (mf *Model) executeCursorQuery(query []bson.M, sort bson.D, limit int64, co
 		options.SetProjection(pMap)
 	}
 
-	return mf.FindAndPopulate(bson.M{"$and": query}, *options, lookups, results)
+	return mf.findAndPopulate(bson.M{"$and": query}, *options, lookups, results)
 
 	// return mf.FindWithOptions(bson.M{"$and": query}, *options, results)
 
 }
 
 func (mf *Model) PaginatedAggregate(example *[]bson.Raw, prevCursor string, nextCursor string, limit int64, pipeline ...interface{}) (Page, error) {
+	op := &Operation{Kind: OperationPaginatedAggregate, Pipeline: pipeline, Result: example}
 
-	ctx, cancel := context.WithTimeout(context.Background(), MediumTimeout*time.Second)
+	err := mf.run(op, func(model *Model, op *Operation) error {
+		example, ok := op.Result.(*[]bson.Raw)
+		if !ok || example == nil {
+			return fmt.Errorf("results must be a non nil *[]bson.Raw, got %T", op.Result)
+		}
+		pipeline, ok := op.Pipeline.([]interface{})
+		if !ok {
+			return fmt.Errorf("pipeline must be a []interface{}, got %T", op.Pipeline)
+		}
+		page, err := model.paginatedAggregate(example, prevCursor, nextCursor, limit, pipeline...)
+		if err == nil {
+			op.Page = &page
+		}
+		return err
+	})
+
+	return operationPage(op, err)
+}
+
+func (mf *Model) paginatedAggregate(example *[]bson.Raw, prevCursor string, nextCursor string, limit int64, pipeline ...interface{}) (Page, error) {
+
+	ctx, cancel := mf.newContext(OperationPaginatedAggregate)
 
 	defer cancel()
 
@@ -120,12 +243,15 @@ func (mf *Model) PaginatedAggregate(example *[]bson.Raw, prevCursor string, next
 	if prevCursor != "" {
 		decodedCursor, err := decodeCursor(prevCursor)
 		if err != nil {
+			mf.logCursorError(ctx, "previous", err)
 			return Page{}, err
 		}
 		if len(decodedCursor) > 0 {
 			value, ok := decodedCursor[0].Value.(int64)
 			if !ok || value <= 0 {
-				return Page{}, errors.New("invalid cursor")
+				err = errors.New("invalid cursor")
+				mf.logCursorError(ctx, "previous", err)
+				return Page{}, err
 			}
 			prev = value
 		}
@@ -135,12 +261,15 @@ func (mf *Model) PaginatedAggregate(example *[]bson.Raw, prevCursor string, next
 	if nextCursor != "" {
 		decodedCursor, err := decodeCursor(nextCursor)
 		if err != nil {
+			mf.logCursorError(ctx, "next", err)
 			return Page{}, err
 		}
 		if len(decodedCursor) > 0 {
 			value, ok := decodedCursor[0].Value.(int64)
 			if !ok || value <= 1 {
-				return Page{}, errors.New("invalid cursor")
+				err = errors.New("invalid cursor")
+				mf.logCursorError(ctx, "next", err)
+				return Page{}, err
 			}
 			next = value
 		}
@@ -152,6 +281,10 @@ func (mf *Model) PaginatedAggregate(example *[]bson.Raw, prevCursor string, next
 		index = prev
 	}
 
+	if scope := mf.scope(bson.M{}); len(scope) > 0 {
+		pipeline = append([]interface{}{bson.M{"$match": scope}}, pipeline...)
+	}
+
 	cur, err := P.New(mf.col).Context(ctx).Page(index).Limit(limit).Aggregate(pipeline...)
 	if err != nil {
 		return Page{}, err
@@ -196,6 +329,39 @@ func (mf *Model) PaginatedAggregate(example *[]bson.Raw, prevCursor string, next
 }
 
 func (mf *Model) PaginatedFind(params PaginationFindParams, results interface{}) (Page, error) {
+	op := &Operation{Kind: OperationPaginatedFind, Filter: params.Query, Options: params, Result: results}
+
+	err := mf.run(op, func(model *Model, op *Operation) error {
+		params, ok := op.Options.(PaginationFindParams)
+		if !ok {
+			return fmt.Errorf("options must be a PaginationFindParams, got %T", op.Options)
+		}
+		params.Query = op.Filter
+		page, err := model.paginatedFind(params, op.Result)
+		if err == nil {
+			op.Page = &page
+		}
+		return err
+	})
+
+	return operationPage(op, err)
+}
+
+// It returns the page of a paginated operation. Middleware that short
+// circuits one must set the page.
+func operationPage(op *Operation, err error) (Page, error) {
+	if err != nil {
+		return Page{}, err
+	}
+
+	if op.Page == nil {
+		return Page{}, fmt.Errorf("%s returned no page", op.Kind)
+	}
+
+	return *op.Page, nil
+}
+
+func (mf *Model) paginatedFind(params PaginationFindParams, results interface{}) (Page, error) {
 
 	var err error
 
@@ -203,12 +369,21 @@ func (mf *Model) PaginatedFind(params PaginationFindParams, results interface{})
 		return Page{}, errors.New("results can't be nil")
 	}
 
+	if resultsType := reflect.TypeOf(results); resultsType.Kind() != reflect.Ptr || resultsType.Elem().Kind() != reflect.Slice {
+		return Page{}, errors.New("results must be a pointer to a slice")
+	}
+
 	params = ensureMandatoryParams(params)
 	shouldSecondarySortOnID := params.PaginatedField != "_id"
 
+	// The count and the find share the timeout of the paginated find.
+	ctx, cancel := mf.newContext(OperationPaginatedFind)
+	defer cancel()
+	model := mf.WithContext(ctx)
+
 	var count int
 	if params.CountTotal {
-		count, err = mf.CountDocuments(params.Query)
+		count, err = model.countDocuments(params.Query)
 		if err != nil {
 			return Page{}, err
 		}
@@ -217,10 +392,17 @@ func (mf *Model) PaginatedFind(params PaginationFindParams, results interface{})
 	queries, sort, err := BuildQueries(params)
 
 	if err != nil {
+		var cursorErr *CursorError
+		if errors.As(err, &cursorErr) {
+			mf.logger().Log(mf.context(), LogLevelWarn, "invalid pagination cursor",
+				"collection", mf.col.Name(),
+				"error", err,
+			)
+		}
 		return Page{}, err
 	}
 
-	err = mf.executeCursorQuery(queries, sort, params.Limit, params.Collation, params.Hint, params.Projection, params.Expansion, results)
+	err = model.executeCursorQuery(queries, sort, params.Limit, params.Collation, params.Hint, params.Projection, params.Expansion, results)
 
 	if err != nil {
 		return Page{}, err
@@ -254,7 +436,12 @@ func (mf *Model) PaginatedFind(params PaginationFindParams, results interface{})
 			firstResult := resultsVal.Index(0).Interface()
 			previousCursor, err = generateCursor(firstResult, params.PaginatedField, shouldSecondarySortOnID)
 			if err != nil {
-				return Page{}, fmt.Errorf("could not create a previous cursor: %s", err)
+				err = fmt.Errorf("could not create a previous cursor: %s", err)
+				mf.logger().Log(mf.context(), LogLevelError, "cannot generate pagination cursor",
+					"collection", mf.col.Name(),
+					"error", err,
+				)
+				return Page{}, err
 			}
 		}
 
@@ -262,7 +449,12 @@ func (mf *Model) PaginatedFind(params PaginationFindParams, results interface{})
 			lastResult := resultsVal.Index(resultsVal.Len() - 1).Interface()
 			nextCursor, err = generateCursor(lastResult, params.PaginatedField, shouldSecondarySortOnID)
 			if err != nil {
-				return Page{}, fmt.Errorf("could not create a next cursor: %s", err)
+				err = fmt.Errorf("could not create a next cursor: %s", err)
+				mf.logger().Log(mf.context(), LogLevelError, "cannot generate pagination cursor",
+					"collection", mf.col.Name(),
+					"error", err,
+				)
+				return Page{}, err
 			}
 		}
 	}
@@ -277,16 +469,33 @@ func (mf *Model) PaginatedFind(params PaginationFindParams, results interface{})
 
 	resultsPtr.Elem().Set(resultsVal)
 
+	if err = mf.afterFind(results); err != nil {
+		return Page{}, err
+	}
+
 	return page, nil
 }
 
 func (mf *Model) FindWithOptions(filter bson.M, option options.FindOptions, results interface{}) error {
+	op := &Operation{Kind: OperationFind, Filter: filter, Options: option, Result: results}
 
-	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+	return mf.run(op, func(model *Model, op *Operation) error {
+		option, _ := op.Options.(options.FindOptions)
+		return model.findWithOptions(op.Filter, option, op.Result)
+	})
+}
+
+func (mf *Model) findWithOptions(filter bson.M, option options.FindOptions, results interface{}) error {
+
+	ctx, cancel := mf.newContext(OperationFind)
 
 	defer cancel()
 
-	cur, err := mf.col.Find(ctx, filter, &option)
+	if option.MaxTime == nil {
+		option.MaxTime = maxTime(ctx)
+	}
+
+	cur, err := mf.col.Find(ctx, mf.scope(filter), &option)
 	if err != nil {
 		return err
 	}
@@ -294,7 +503,7 @@ func (mf *Model) FindWithOptions(filter bson.M, option options.FindOptions, resu
 	if err != nil {
 		return err
 	}
-	return nil
+	return mf.afterFind(results)
 }
 
 func (mf *Model) FindOneAndPopulate(filter bson.M, findOptions options.FindOptions, populate []PopulateOptions, result interface{}) error {
@@ -303,12 +512,44 @@ func (mf *Model) FindOneAndPopulate(filter bson.M, findOptions options.FindOptio
 }
 
 func (mf *Model) FindAndPopulate(filter bson.M, option options.FindOptions, populate []PopulateOptions, results interface{}) error {
+	op := &Operation{Kind: OperationFind, Filter: filter, Populate: populate, Options: option, Result: results}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		option, _ := op.Options.(options.FindOptions)
+		if err := model.findAndPopulate(op.Filter, option, op.Populate, op.Result); err != nil {
+			return err
+		}
+		return model.afterFind(op.Result)
+	})
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+func (mf *Model) findAndPopulate(filter bson.M, option options.FindOptions, populate []PopulateOptions, results interface{}) error {
+
+	ctx, cancel := mf.newContext(OperationFind)
 
 	defer cancel()
 
-	var limit = 10
+	pipeline := mf.buildPopulatePipeline(filter, option, populate, 10)
+
+	cur, err := mf.col.Aggregate(ctx, pipeline, &options.AggregateOptions{MaxTime: maxTime(ctx)})
+
+	if err != nil {
+		return err
+	}
+
+	if err := cur.All(ctx, results); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// It translates find options and populate options into an aggregation
+// pipeline. The limit stage is omitted when neither the options nor the
+// default limit set one.
+func (mf *Model) buildPopulatePipeline(filter bson.M, option options.FindOptions, populate []PopulateOptions, defaultLimit int) mongo.Pipeline {
+
+	var limit = defaultLimit
 
 	pipeline := mongo.Pipeline{}
 
@@ -317,11 +558,7 @@ func (mf *Model) FindAndPopulate(filter bson.M, option options.FindOptions, popu
 	}
 
 	matchStage := bson.D{
-		{Key: "$match", Value: filter},
-	}
-
-	limitStage := bson.D{
-		{Key: "$limit", Value: limit},
+		{Key: "$match", Value: mf.scope(filter)},
 	}
 
 	if option.Sort != nil {
@@ -333,7 +570,15 @@ func (mf *Model) FindAndPopulate(filter bson.M, option options.FindOptions, popu
 
 	}
 
-	pipeline = append(pipeline, matchStage, limitStage)
+	pipeline = append(pipeline, matchStage)
+
+	if limit > 0 {
+		limitStage := bson.D{
+			{Key: "$limit", Value: limit},
+		}
+
+		pipeline = append(pipeline, limitStage)
+	}
 
 	if option.Projection != nil {
 		projectionStage := bson.D{
@@ -343,30 +588,29 @@ func (mf *Model) FindAndPopulate(filter bson.M, option options.FindOptions, popu
 		pipeline = append(pipeline, projectionStage)
 	}
 
-	for _, value := range populate {
+	for _, value := range mf.resolvePopulate(populate) {
 		pipeline = append(pipeline, BuildLookupStage(value)...)
 	}
 
-	cur, err := mf.col.Aggregate(ctx, pipeline)
-
-	if err != nil {
-		return err
-	}
+	return pipeline
+}
 
-	if err := cur.All(ctx, results); err != nil {
-		return err
-	}
+func (mf *Model) Aggregate(pipeline mongo.Pipeline, results interface{}) error {
+	op := &Operation{Kind: OperationAggregate, Pipeline: pipeline, Result: results}
 
-	return nil
+	return mf.run(op, func(model *Model, op *Operation) error {
+		pipeline, _ := op.Pipeline.(mongo.Pipeline)
+		return model.aggregate(pipeline, op.Result)
+	})
 }
 
-func (mf *Model) Aggregate(pipeline mongo.Pipeline, results interface{}) error {
+func (mf *Model) aggregate(pipeline mongo.Pipeline, results interface{}) error {
 
-	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+	ctx, cancel := mf.newContext(OperationAggregate)
 
 	defer cancel()
 
-	cur, err := mf.col.Aggregate(ctx, pipeline)
+	cur, err := mf.col.Aggregate(ctx, pipeline, &options.AggregateOptions{MaxTime: maxTime(ctx)})
 
 	if err != nil {
 		return err
@@ -392,6 +636,26 @@ func BuildLookupStage(populate PopulateOptions) []bson.D {
 		},
 	}
 
+	if populate.SoftDeleteField != "" && !populate.WithDeleted {
+		lookup = bson.D{
+			{Key: "$lookup",
+				Value: bson.D{
+					{Key: "from", Value: populate.Collection},
+					{Key: "let", Value: bson.D{{Key: "local", Value: "$" + populate.LocalField}}},
+					{Key: "pipeline", Value: bson.A{
+						bson.D{{Key: "$match", Value: bson.D{
+							{Key: "$expr", Value: bson.D{{Key: "$in", Value: bson.A{"$_id",
+								bson.D{{Key: "$cond", Value: bson.A{bson.D{{Key: "$isArray", Value: "$$local"}}, "$$local", bson.A{"$$local"}}}},
+							}}}},
+							{Key: populate.SoftDeleteField, Value: nil},
+						}}},
+					}},
+					{Key: "as", Value: populate.As},
+				},
+			},
+		}
+	}
+
 	addFields :=
 
 		bson.D{
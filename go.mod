@@ -3,6 +3,7 @@ module github.com/wezard-it/yamgo
 go 1.19
 
 require (
+	github.com/gobeam/mongo-go-pagination v0.0.8
 	github.com/ory/dockertest/v3 v3.9.1
 	github.com/stretchr/testify v1.7.1
 	go.mongodb.org/mongo-driver v1.10.3
@@ -40,7 +41,6 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/gobeam/mongo-go-pagination v0.0.8 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
 	github.com/klauspost/compress v1.13.6 // indirect
 	github.com/montanaflynn/stats v0.0.0-20171201202039-1bf9dbcd8cbe // indirect
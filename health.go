@@ -0,0 +1,176 @@
+package yamgo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+)
+
+type (
+	HealthStatus struct {
+		Healthy          bool          `json:"healthy"`
+		Latency          time.Duration `json:"latency"`
+		Topology         string        `json:"topology"`
+		PrimaryAvailable bool          `json:"primary_available"`
+		ServerVersion    string        `json:"server_version,omitempty"`
+		Pool             PoolStats     `json:"pool"`
+		Error            string        `json:"error,omitempty"`
+	}
+
+	PoolStats struct {
+		Open           int64 `json:"open"`
+		InUse          int64 `json:"in_use"`
+		Created        int64 `json:"created"`
+		Closed         int64 `json:"closed"`
+		CheckOutFailed int64 `json:"check_out_failed"`
+		Cleared        int64 `json:"cleared"`
+	}
+)
+
+// healthMonitor keeps the last topology description and the pool counters
+// reported by the driver monitors.
+type healthMonitor struct {
+	mu       sync.RWMutex
+	topology description.Topology
+
+	created        int64
+	closed         int64
+	checkedOut     int64
+	checkedIn      int64
+	checkOutFailed int64
+	cleared        int64
+}
+
+func (h *healthMonitor) serverMonitor() *event.ServerMonitor {
+	return &event.ServerMonitor{
+		TopologyDescriptionChanged: func(evt *event.TopologyDescriptionChangedEvent) {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.topology = evt.NewDescription
+		},
+	}
+}
+
+func (h *healthMonitor) poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCreated:
+				atomic.AddInt64(&h.created, 1)
+			case event.ConnectionClosed:
+				atomic.AddInt64(&h.closed, 1)
+			case event.GetSucceeded:
+				atomic.AddInt64(&h.checkedOut, 1)
+			case event.ConnectionReturned:
+				atomic.AddInt64(&h.checkedIn, 1)
+			case event.GetFailed:
+				atomic.AddInt64(&h.checkOutFailed, 1)
+			case event.PoolCleared:
+				atomic.AddInt64(&h.cleared, 1)
+			}
+		},
+	}
+}
+
+func (h *healthMonitor) poolStats() PoolStats {
+	created := atomic.LoadInt64(&h.created)
+	closed := atomic.LoadInt64(&h.closed)
+
+	return PoolStats{
+		Open:           created - closed,
+		InUse:          atomic.LoadInt64(&h.checkedOut) - atomic.LoadInt64(&h.checkedIn),
+		Created:        created,
+		Closed:         closed,
+		CheckOutFailed: atomic.LoadInt64(&h.checkOutFailed),
+		Cleared:        atomic.LoadInt64(&h.cleared),
+	}
+}
+
+func (h *healthMonitor) currentTopology() description.Topology {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.topology
+}
+
+// Health pings the database and reports the state of the connection.
+func (c *Client) Health(ctx context.Context) (HealthStatus, error) {
+	status := HealthStatus{Pool: c.health.poolStats()}
+
+	start := time.Now()
+	err := c.client.Ping(ctx, nil)
+	status.Latency = time.Since(start)
+
+	topology := c.health.currentTopology()
+	status.Topology = topology.Kind.String()
+	status.PrimaryAvailable = topology.HasWritableServer()
+
+	if err != nil {
+		status.Error = err.Error()
+		c.currentLogger().Log(ctx, LogLevelWarn, "health check failed", "error", err)
+		return status, err
+	}
+
+	var buildInfo struct {
+		Version string `bson:"version"`
+	}
+	err = c.Database.RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo)
+	if err != nil {
+		status.Error = err.Error()
+		return status, err
+	}
+
+	status.ServerVersion = buildInfo.Version
+	status.Healthy = true
+
+	return status, nil
+}
+
+// LivenessHandler answers 200 while the database can be pinged and 503
+// otherwise, with the health status as body.
+func (c *Client) LivenessHandler() http.Handler {
+	return c.healthHandler(false)
+}
+
+// ReadinessHandler is like LivenessHandler but also requires a primary to be
+// available, so the instance only receives traffic it can write for.
+func (c *Client) ReadinessHandler() http.Handler {
+	return c.healthHandler(true)
+}
+
+func (c *Client) healthHandler(requirePrimary bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), ShortTimeout*time.Second)
+		defer cancel()
+
+		status, err := c.Health(ctx)
+
+		code := http.StatusOK
+		if err != nil || (requirePrimary && !status.PrimaryAvailable) {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
+
+// Health reports the state of the default client connection.
+func Health(ctx context.Context) (HealthStatus, error) {
+	return _client.Health(ctx)
+}
+
+func LivenessHandler() http.Handler {
+	return _client.LivenessHandler()
+}
+
+func ReadinessHandler() http.Handler {
+	return _client.ReadinessHandler()
+}
@@ -0,0 +1,115 @@
+package yamgo
+
+import (
+	"context"
+	"reflect"
+)
+
+type (
+	// BeforeInserter is implemented by documents that prepare themselves
+	// before InsertOne, InsertMany and Save insert them. An error aborts the
+	// insert.
+	BeforeInserter interface {
+		BeforeInsert(ctx context.Context) error
+	}
+
+	// AfterInserter is implemented by documents notified once inserted.
+	AfterInserter interface {
+		AfterInsert(ctx context.Context) error
+	}
+
+	// BeforeUpdater is implemented by documents that prepare themselves
+	// before they replace a stored document through ReplaceOne,
+	// FindOneAndReplace and Save. An error aborts the write.
+	BeforeUpdater interface {
+		BeforeUpdate(ctx context.Context) error
+	}
+
+	// AfterFinder is implemented by documents decorated once decoded by the
+	// Find methods and PaginatedFind. An error is returned by the method.
+	AfterFinder interface {
+		AfterFind(ctx context.Context) error
+	}
+)
+
+var afterFinderType = reflect.TypeOf((*AfterFinder)(nil)).Elem()
+
+// It runs the BeforeInsert hook of a document. Struct values are copied so
+// hooks with a pointer receiver can change them, and the copy must be used in
+// place of doc.
+func (mf *Model) beforeInsert(doc interface{}) (interface{}, error) {
+	target := hookTarget(doc)
+	if hook, ok := target.(BeforeInserter); ok {
+		return target, hook.BeforeInsert(mf.context())
+	}
+
+	return doc, nil
+}
+
+func (mf *Model) afterInsert(doc interface{}) error {
+	if hook, ok := hookTarget(doc).(AfterInserter); ok {
+		return hook.AfterInsert(mf.context())
+	}
+
+	return nil
+}
+
+// It runs the BeforeUpdate hook of a replacement, like beforeInsert.
+func (mf *Model) beforeUpdate(doc interface{}) (interface{}, error) {
+	target := hookTarget(doc)
+	if hook, ok := target.(BeforeUpdater); ok {
+		return target, hook.BeforeUpdate(mf.context())
+	}
+
+	return doc, nil
+}
+
+// It runs the AfterFind hook of a decoded document, or of each element of a
+// decoded slice.
+func (mf *Model) afterFind(results interface{}) error {
+	if hook, ok := results.(AfterFinder); ok {
+		return hook.AfterFind(mf.context())
+	}
+
+	val := reflect.ValueOf(results)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Slice {
+		return nil
+	}
+
+	elems := val.Elem()
+	elemType := elems.Type().Elem()
+	if !elemType.Implements(afterFinderType) && !reflect.PtrTo(elemType).Implements(afterFinderType) {
+		return nil
+	}
+
+	for i := 0; i < elems.Len(); i++ {
+		elem := elems.Index(i)
+		if elem.Kind() != reflect.Ptr && elem.Kind() != reflect.Interface {
+			elem = elem.Addr()
+		}
+		if elem.IsNil() {
+			continue
+		}
+		if hook, ok := elem.Interface().(AfterFinder); ok {
+			if err := hook.AfterFind(mf.context()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// It returns a pointer to a copy of struct values, so their pointer receiver
+// methods can be called.
+func hookTarget(doc interface{}) interface{} {
+	val := reflect.ValueOf(doc)
+	if val.Kind() != reflect.Struct {
+		return doc
+	}
+
+	copied := reflect.New(val.Type())
+	copied.Elem().Set(val)
+
+	return copied.Interface()
+}
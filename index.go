@@ -0,0 +1,614 @@
+package yamgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index key types, used as the values of Index.Keys.
+const (
+	IndexAsc      = 1
+	IndexDesc     = -1
+	IndexText     = "text"
+	Index2DSphere = "2dsphere"
+)
+
+type (
+	// Index describes an index of a collection. Name defaults to the one the
+	// server would generate, e.g. "status_1_createdAt_-1".
+	Index struct {
+		Name   string
+		Keys   bson.D
+		Unique bool
+		Sparse bool
+		// Hidden indexes are maintained but not used by queries.
+		Hidden bool
+		// ExpireAfter makes a TTL index, removing documents once the indexed
+		// date is older than the duration.
+		ExpireAfter   *time.Duration
+		PartialFilter bson.M
+		// Weights of the fields of a text index.
+		Weights bson.M
+	}
+
+	SyncIndexesOptions struct {
+		// DryRun only reports the changes SyncIndexes would make.
+		DryRun bool
+		// DropStale drops the indexes that are not declared, and the ones
+		// whose definition changed so they can be created again.
+		DropStale bool
+	}
+
+	SyncIndexesResult struct {
+		Created []string
+		Dropped []string
+		// Stale lists the existing indexes that are not declared.
+		Stale []string
+		// Changed lists the declared indexes whose existing definition
+		// differs. They are only rebuilt with DropStale.
+		Changed []string
+	}
+
+	// It is an index as returned by listIndexes.
+	indexSpec struct {
+		Name                    string `bson:"name"`
+		Key                     bson.D `bson:"key"`
+		Unique                  bool   `bson:"unique"`
+		Sparse                  bool   `bson:"sparse"`
+		Hidden                  bool   `bson:"hidden"`
+		ExpireAfterSeconds      *int64 `bson:"expireAfterSeconds"`
+		PartialFilterExpression bson.M `bson:"partialFilterExpression"`
+		Weights                 bson.M `bson:"weights"`
+	}
+)
+
+// WithIndexes declares indexes of the model collection, created by
+// SyncIndexes together with the ones declared by the index tags of the model
+// schema.
+func WithIndexes(indexes ...Index) ModelOption {
+	return func(mf *Model) {
+		mf.indexes = append(mf.indexes, indexes...)
+	}
+}
+
+// SyncIndexes creates the declared indexes that are missing from the
+// collection and reports, or drops with DropStale, the ones that are not
+// declared anymore. The _id index is never touched.
+//
+// Indexes are declared with WithIndexes and with index tags on the fields of
+// the schema set by WithSchema:
+//
+//	index:"[asc|desc|text|2dsphere][,unique][,sparse][,ttl=DURATION][,name=NAME]"
+//
+// Fields tagged with the same name form a compound index, in the order of
+// the fields. Nested fields are indexed by their bson path.
+func (mf *Model) SyncIndexes(opts SyncIndexesOptions) (*SyncIndexesResult, error) {
+
+	declared, err := mf.declaredIndexes()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := mf.newContext(OperationIndexes)
+
+	defer cancel()
+
+	existing, err := mf.listIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncIndexesResult{}
+	present := make(map[string]bool, len(existing))
+	drop := []string{}
+
+	for _, index := range existing {
+		if index.Name == "_id_" {
+			continue
+		}
+
+		wanted, ok := declared[index.Name]
+		switch {
+		case !ok:
+			result.Stale = append(result.Stale, index.Name)
+			if opts.DropStale {
+				drop = append(drop, index.Name)
+			}
+		case !sameIndex(wanted, index):
+			result.Changed = append(result.Changed, index.Name)
+			if opts.DropStale {
+				drop = append(drop, index.Name)
+			} else {
+				present[index.Name] = true
+			}
+		default:
+			present[index.Name] = true
+		}
+	}
+
+	create := []mongo.IndexModel{}
+	for _, index := range sortedIndexes(declared) {
+		if !present[index.Name] {
+			create = append(create, index.model())
+			result.Created = append(result.Created, index.Name)
+		}
+	}
+
+	if opts.DryRun {
+		result.Dropped = drop
+		return result, nil
+	}
+
+	for _, name := range drop {
+		if _, err = mf.col.Indexes().DropOne(ctx, name); err != nil {
+			return result, err
+		}
+		result.Dropped = append(result.Dropped, name)
+		mf.logger().Log(ctx, LogLevelInfo, "dropped index", "collection", mf.col.Name(), "index", name)
+	}
+
+	if len(create) > 0 {
+		if _, err = mf.col.Indexes().CreateMany(ctx, create); err != nil {
+			return result, err
+		}
+		mf.logger().Log(ctx, LogLevelInfo, "created indexes", "collection", mf.col.Name(), "indexes", result.Created)
+	}
+
+	return result, nil
+}
+
+// It collects the indexes declared by the model options and schema tags by
+// name.
+func (mf *Model) declaredIndexes() (map[string]Index, error) {
+	indexes := append([]Index{}, mf.indexes...)
+
+	if mf.schema != nil {
+		tagged, err := taggedIndexes(mf.schema)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, tagged...)
+	}
+
+	declared := make(map[string]Index, len(indexes))
+	for _, index := range indexes {
+		if len(index.Keys) == 0 {
+			return nil, errors.New("index has no keys")
+		}
+		index.Name = index.name()
+		if _, ok := declared[index.Name]; ok {
+			return nil, fmt.Errorf("index %s is declared twice", index.Name)
+		}
+		declared[index.Name] = index
+	}
+
+	return declared, nil
+}
+
+// It reads the current indexes of the collection.
+func (mf *Model) listIndexes(ctx context.Context) ([]Index, error) {
+	cur, err := mf.col.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := []indexSpec{}
+	if err = cur.All(ctx, &specs); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]Index, 0, len(specs))
+	for _, spec := range specs {
+		indexes = append(indexes, spec.index())
+	}
+
+	return indexes, nil
+}
+
+func (index Index) name() string {
+	if index.Name != "" {
+		return index.Name
+	}
+
+	parts := make([]string, 0, len(index.Keys))
+	for _, key := range index.Keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+func (index Index) model() mongo.IndexModel {
+	opts := options.Index().SetName(index.name())
+
+	if index.Unique {
+		opts.SetUnique(true)
+	}
+	if index.Sparse {
+		opts.SetSparse(true)
+	}
+	if index.Hidden {
+		opts.SetHidden(true)
+	}
+	if index.ExpireAfter != nil {
+		opts.SetExpireAfterSeconds(int32(index.ExpireAfter.Seconds()))
+	}
+	if index.PartialFilter != nil {
+		opts.SetPartialFilterExpression(index.PartialFilter)
+	}
+	if index.Weights != nil {
+		opts.SetWeights(index.Weights)
+	}
+
+	return mongo.IndexModel{Keys: index.Keys, Options: opts}
+}
+
+func (spec indexSpec) index() Index {
+	index := Index{
+		Name:          spec.Name,
+		Keys:          spec.Key,
+		Unique:        spec.Unique,
+		Sparse:        spec.Sparse,
+		Hidden:        spec.Hidden,
+		PartialFilter: spec.PartialFilterExpression,
+		Weights:       spec.Weights,
+	}
+
+	if spec.ExpireAfterSeconds != nil {
+		expireAfter := time.Duration(*spec.ExpireAfterSeconds) * time.Second
+		index.ExpireAfter = &expireAfter
+	}
+
+	// Text indexes are stored under _fts and _ftsx, with the indexed fields
+	// in the weights.
+	keys := bson.D{}
+	for _, key := range spec.Key {
+		switch key.Key {
+		case "_fts":
+			fields := make([]string, 0, len(spec.Weights))
+			for field := range spec.Weights {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				keys = append(keys, bson.E{Key: field, Value: IndexText})
+			}
+		case "_ftsx":
+		default:
+			keys = append(keys, key)
+		}
+	}
+	index.Keys = keys
+
+	return index
+}
+
+// It compares a declared index with an existing one. Hidden is ignored, as it
+// can be changed without rebuilding the index.
+func sameIndex(declared Index, existing Index) bool {
+	if declared.Unique != existing.Unique || declared.Sparse != existing.Sparse {
+		return false
+	}
+
+	if (declared.ExpireAfter == nil) != (existing.ExpireAfter == nil) ||
+		declared.ExpireAfter != nil && int64(declared.ExpireAfter.Seconds()) != int64(existing.ExpireAfter.Seconds()) {
+		return false
+	}
+
+	if !sameDocument(declared.PartialFilter, existing.PartialFilter) {
+		return false
+	}
+
+	if declared.Weights != nil && !sameDocument(declared.Weights, existing.Weights) {
+		return false
+	}
+
+	return sameKeys(declared.Keys, existing.Keys)
+}
+
+// It compares index keys, ignoring the order of text fields and the numeric
+// type of directions.
+func sameKeys(a bson.D, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	normalize := func(keys bson.D) []string {
+		normalized := make([]string, 0, len(keys))
+		texts := []string{}
+		for _, key := range keys {
+			value := key.Value
+			if number, ok := toInt64(value); ok {
+				value = number
+			} else if number, ok := value.(float64); ok {
+				value = int64(number)
+			}
+			if value == IndexText {
+				texts = append(texts, key.Key)
+				continue
+			}
+			normalized = append(normalized, fmt.Sprintf("%s:%v", key.Key, value))
+		}
+		sort.Strings(texts)
+		return append(normalized, texts...)
+	}
+
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// It compares documents after a round trip through bson, so they hold the
+// same types.
+func sameDocument(a bson.M, b bson.M) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	roundTrip := func(doc bson.M) bson.M {
+		data, err := bson.Marshal(doc)
+		if err != nil {
+			return doc
+		}
+		decoded := bson.M{}
+		if err = bson.Unmarshal(data, &decoded); err != nil {
+			return doc
+		}
+		return decoded
+	}
+
+	return reflect.DeepEqual(roundTrip(a), roundTrip(b))
+}
+
+func sortedIndexes(indexes map[string]Index) []Index {
+	sorted := make([]Index, 0, len(indexes))
+	for _, index := range indexes {
+		sorted = append(sorted, index)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// It builds the indexes declared by the index tags of a schema struct.
+func taggedIndexes(schema interface{}) ([]Index, error) {
+	typ := reflect.TypeOf(schema)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, errors.New("schema must be a struct")
+	}
+
+	indexes := []*Index{}
+	named := map[string]*Index{}
+
+	if err := collectIndexes(typ, "", &indexes, named, map[reflect.Type]bool{}); err != nil {
+		return nil, err
+	}
+
+	result := make([]Index, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, *index)
+	}
+
+	return result, nil
+}
+
+func collectIndexes(typ reflect.Type, prefix string, indexes *[]*Index, named map[string]*Index, visiting map[reflect.Type]bool) error {
+	if visiting[typ] {
+		return nil
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("bson") == "-" {
+			continue
+		}
+
+		key, inline := bsonKey(field)
+		if inline && field.Type.Kind() == reflect.Struct {
+			if err := collectIndexes(field.Type, prefix, indexes, named, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		path := prefix + key
+
+		if tag, ok := field.Tag.Lookup("index"); ok && tag != "-" {
+			if err := addTaggedIndex(path, tag, indexes, named); err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+		}
+
+		nested := field.Type
+		for nested.Kind() == reflect.Ptr || nested.Kind() == reflect.Slice || nested.Kind() == reflect.Array {
+			nested = nested.Elem()
+		}
+		if _, ok := primitiveTypes[nested]; !ok && nested.Kind() == reflect.Struct {
+			if err := collectIndexes(nested, path+".", indexes, named, visiting); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func addTaggedIndex(path string, tag string, indexes *[]*Index, named map[string]*Index) error {
+	parts := strings.Split(tag, ",")
+
+	var value interface{}
+	switch parts[0] {
+	case "", "asc":
+		value = IndexAsc
+	case "desc":
+		value = IndexDesc
+	case IndexText, Index2DSphere:
+		value = parts[0]
+	default:
+		return fmt.Errorf("unknown index type %q", parts[0])
+	}
+
+	index := &Index{}
+	for _, opt := range parts[1:] {
+		name, param, _ := strings.Cut(opt, "=")
+		switch name {
+		case "name":
+			if existing, ok := named[param]; ok {
+				index = existing
+			} else {
+				named[param] = index
+			}
+			index.Name = param
+		}
+	}
+
+	for _, opt := range parts[1:] {
+		name, param, _ := strings.Cut(opt, "=")
+		switch name {
+		case "name":
+		case "unique":
+			index.Unique = true
+		case "sparse":
+			index.Sparse = true
+		case "ttl":
+			expireAfter, err := time.ParseDuration(param)
+			if err != nil {
+				return fmt.Errorf("invalid ttl %q", param)
+			}
+			index.ExpireAfter = &expireAfter
+		default:
+			return fmt.Errorf("unknown index option %q", opt)
+		}
+	}
+
+	if len(index.Keys) == 0 {
+		*indexes = append(*indexes, index)
+	}
+	index.Keys = append(index.Keys, bson.E{Key: path, Value: value})
+
+	return nil
+}
+
+// IndexUsage reports how often an index was used since Since, the last
+// restart of the server or creation of the index.
+type IndexUsage struct {
+	Name  string
+	Keys  bson.D
+	Host  string
+	Ops   int64
+	Since time.Time
+}
+
+// CreateIndex creates an index and returns its name.
+func (mf *Model) CreateIndex(index Index) (string, error) {
+
+	if len(index.Keys) == 0 {
+		return "", errors.New("index has no keys")
+	}
+
+	ctx, cancel := mf.newContext(OperationIndexes)
+
+	defer cancel()
+
+	return mf.col.Indexes().CreateOne(ctx, index.model())
+}
+
+// ListIndexes returns the indexes of the collection.
+func (mf *Model) ListIndexes() ([]Index, error) {
+
+	ctx, cancel := mf.newContext(OperationIndexes)
+
+	defer cancel()
+
+	return mf.listIndexes(ctx)
+}
+
+// DropIndex drops the index with the given name.
+func (mf *Model) DropIndex(name string) error {
+
+	ctx, cancel := mf.newContext(OperationIndexes)
+
+	defer cancel()
+
+	_, err := mf.col.Indexes().DropOne(ctx, name)
+
+	return err
+}
+
+// HideIndex hides an index from the query planner while keeping it up to
+// date, to check the impact of dropping it.
+func (mf *Model) HideIndex(name string) error {
+	return mf.setIndexHidden(name, true)
+}
+
+// UnhideIndex makes a hidden index available to the query planner again.
+func (mf *Model) UnhideIndex(name string) error {
+	return mf.setIndexHidden(name, false)
+}
+
+// IndexUsage returns the usage statistics of the indexes of the collection,
+// one entry per index and server.
+func (mf *Model) IndexUsage() ([]IndexUsage, error) {
+
+	ctx, cancel := mf.newContext(OperationIndexes)
+
+	defer cancel()
+
+	cur, err := mf.col.Aggregate(ctx, mongo.Pipeline{{{Key: "$indexStats", Value: bson.M{}}}})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []struct {
+		Name     string `bson:"name"`
+		Key      bson.D `bson:"key"`
+		Host     string `bson:"host"`
+		Accesses struct {
+			Ops   int64     `bson:"ops"`
+			Since time.Time `bson:"since"`
+		} `bson:"accesses"`
+	}{}
+
+	if err = cur.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	usage := make([]IndexUsage, 0, len(stats))
+	for _, stat := range stats {
+		usage = append(usage, IndexUsage{
+			Name:  stat.Name,
+			Keys:  stat.Key,
+			Host:  stat.Host,
+			Ops:   stat.Accesses.Ops,
+			Since: stat.Accesses.Since,
+		})
+	}
+
+	return usage, nil
+}
+
+func (mf *Model) setIndexHidden(name string, hidden bool) error {
+
+	ctx, cancel := mf.newContext(OperationCollMod)
+
+	defer cancel()
+
+	return mf.col.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: mf.col.Name()},
+		{Key: "index", Value: bson.M{"name": name, "hidden": hidden}},
+	}).Err()
+}
@@ -1,15 +1,74 @@
 package yamgo
 
 import (
-	"context"
-	"time"
+	"errors"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (mf *Model) InsertOne(record interface{}) (res *mongo.InsertOneResult, err error) {
+type (
+	// InsertFailure is a document rejected by InsertMany. ID is its _id, nil
+	// when it had none and the driver generated it.
+	InsertFailure struct {
+		WriteFailure
+		ID interface{}
+	}
+
+	// BulkInsertError is returned by InsertMany when some documents were
+	// rejected. Unprocessed lists the documents an ordered insert never sent
+	// after the first failure, which can be retried together with Failures.
+	BulkInsertError struct {
+		InsertedIDs []interface{}
+		Failures    []InsertFailure
+		Unprocessed []int
+	}
+)
+
+func (e *BulkInsertError) Error() string {
+	if len(e.Failures) == 0 {
+		return "insert many failed"
+	}
+	return fmt.Sprintf("insert many failed: %d documents failed, first error at index %d: %s",
+		len(e.Failures), e.Failures[0].Index, e.Failures[0].Message)
+}
+
+// FailedIndexes returns the indexes of the rejected documents.
+func (e *BulkInsertError) FailedIndexes() []int {
+	indexes := make([]int, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		indexes = append(indexes, failure.Index)
+	}
+	return indexes
+}
+
+func (mf *Model) InsertOne(record interface{}) (*mongo.InsertOneResult, error) {
+	op := &Operation{Kind: OperationInsertOne, Document: record}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		op.Result, err = model.insertOne(op.Document)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.InsertOneResult)
+
+	return res, err
+}
+
+func (mf *Model) insertOne(record interface{}) (res *mongo.InsertOneResult, err error) {
+
+	if record, err = mf.beforeInsert(record); err != nil {
+		return nil, err
+	}
+
+	record = mf.stampDocument(record, true)
+
+	if err = mf.validate(record); err != nil {
+		return nil, err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), MediumTimeout*time.Second)
+	ctx, cancel := mf.newContext(OperationInsertOne)
 
 	defer cancel()
 	res, err = mf.col.InsertOne(ctx, record)
@@ -18,19 +77,99 @@ func (mf *Model) InsertOne(record interface{}) (res *mongo.InsertOneResult, err
 		return nil, err
 	}
 
+	return res, mf.afterInsert(record)
+}
+
+// InsertMany inserts the records in order; an unordered insert, requested with
+// SetOrdered(false), keeps going past rejected documents. When some documents
+// are rejected the result lists the inserted ids and the error is a
+// *BulkInsertError. Nothing is inserted when a record fails validation, and
+// the AfterInsert hooks only run when every record was inserted.
+func (mf *Model) InsertMany(records []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	op := &Operation{Kind: OperationInsertMany, Documents: records, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.InsertManyOptions)
+		op.Result, err = model.insertMany(op.Documents, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.InsertManyResult)
+
 	return res, err
 }
 
-func (mf *Model) InsertMany(records []interface{}) (res *mongo.InsertManyResult, err error) {
+func (mf *Model) insertMany(records []interface{}, opts ...*options.InsertManyOptions) (res *mongo.InsertManyResult, err error) {
+
+	prepared := make([]interface{}, len(records))
+	for i, record := range records {
+		if prepared[i], err = mf.beforeInsert(record); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+		prepared[i] = mf.stampDocument(prepared[i], true)
+		if err = mf.validate(prepared[i]); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+	ctx, cancel := mf.newContext(OperationInsertMany)
 	defer cancel()
 
-	res, err = mf.col.InsertMany(ctx, records)
+	res, err = mf.col.InsertMany(ctx, prepared, opts...)
+
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil && res != nil {
+		ordered := true
+		if option := options.MergeInsertManyOptions(opts...); option.Ordered != nil {
+			ordered = *option.Ordered
+		}
+
+		insertErr := newBulkInsertError(prepared, res.InsertedIDs, bulkErr, ordered)
+
+		return &mongo.InsertManyResult{InsertedIDs: insertErr.InsertedIDs}, insertErr
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	return res, err
+	for _, record := range prepared {
+		if err = mf.afterInsert(record); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// It builds the error of a partially failed insert. The driver only returns
+// the ids of the inserted documents, in order: it drops the failed ones and,
+// for ordered inserts, the ones after the first failure. The ids of the failed
+// documents are read from the documents themselves.
+func newBulkInsertError(docs []interface{}, ids []interface{}, bulkErr mongo.BulkWriteException, ordered bool) *BulkInsertError {
+
+	insertErr := &BulkInsertError{InsertedIDs: append([]interface{}{}, ids...)}
+	first := len(docs)
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		failure := InsertFailure{
+			WriteFailure: WriteFailure{Index: writeErr.Index, Code: writeErr.Code, Message: writeErr.Message},
+		}
+		if writeErr.Index >= 0 && writeErr.Index < len(docs) {
+			failure.ID, _ = documentID(docs[writeErr.Index])
+		}
+		insertErr.Failures = append(insertErr.Failures, failure)
+
+		if writeErr.Index < first {
+			first = writeErr.Index
+		}
+	}
+
+	if ordered {
+		for index := first + 1; index < len(docs); index++ {
+			insertErr.Unprocessed = append(insertErr.Unprocessed, index)
+		}
+	}
+
+	return insertErr
 }
@@ -0,0 +1,80 @@
+//go:build go1.23
+
+package yamgo
+
+import (
+	"iter"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindSeq returns an iterator over the documents matching filter. The cursor
+// is opened when the iteration starts and closed when it ends, including on
+// break. A failure is yielded once as the last pair.
+func (mf *Model) FindSeq(filter bson.M, opts ...*options.FindOptions) iter.Seq2[bson.Raw, error] {
+	return func(yield func(bson.Raw, error) bool) {
+		err := mf.FindEach(filter, func(doc bson.Raw) error {
+			if !yield(doc, nil) {
+				return errStopIteration
+			}
+			return nil
+		}, opts...)
+
+		if err != nil && err != errStopIteration {
+			yield(nil, err)
+		}
+	}
+}
+
+// AggregateSeq is the iterator counterpart of AggregateEach.
+func (mf *Model) AggregateSeq(pipeline mongo.Pipeline, opts ...*options.AggregateOptions) iter.Seq2[bson.Raw, error] {
+	return func(yield func(bson.Raw, error) bool) {
+		err := mf.AggregateEach(pipeline, func(doc bson.Raw) error {
+			if !yield(doc, nil) {
+				return errStopIteration
+			}
+			return nil
+		}, opts...)
+
+		if err != nil && err != errStopIteration {
+			yield(nil, err)
+		}
+	}
+}
+
+// FindSeq returns an iterator over the documents matching filter decoded
+// into T.
+func (tm *TypedModel[T]) FindSeq(filter bson.M, opts ...*options.FindOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := tm.FindEach(filter, func(doc T) error {
+			if !yield(doc, nil) {
+				return errStopIteration
+			}
+			return nil
+		}, opts...)
+
+		if err != nil && err != errStopIteration {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// AggregateSeq is the iterator counterpart of AggregateEach.
+func (tm *TypedModel[T]) AggregateSeq(pipeline mongo.Pipeline, opts ...*options.AggregateOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := tm.AggregateEach(pipeline, func(doc T) error {
+			if !yield(doc, nil) {
+				return errStopIteration
+			}
+			return nil
+		}, opts...)
+
+		if err != nil && err != errStopIteration {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package yamgo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota - 1
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+	LogLevelSilent
+)
+
+// Logger receives the structured records emitted by yamgo. Key-value pairs
+// alternate between a string key and its value.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{})
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Log(context.Context, LogLevel, string, ...interface{}) {}
+
+// It drops the records below the configured level.
+type leveledLogger struct {
+	logger Logger
+	level  LogLevel
+}
+
+func (l leveledLogger) Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	if level < l.level || l.level >= LogLevelSilent {
+		return
+	}
+
+	l.logger.Log(ctx, level, msg, keysAndValues...)
+}
+
+func newLeveledLogger(logger Logger, level LogLevel) Logger {
+	if logger == nil {
+		return noopLogger{}
+	}
+
+	return leveledLogger{logger: logger, level: level}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+}
+
+// NewStdLogger adapts a standard library logger, writing records as
+// "LEVEL msg key=value ...".
+func NewStdLogger(logger *log.Logger) Logger {
+	return stdLogger{logger: logger}
+}
+
+func (l stdLogger) Log(_ context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	var sb strings.Builder
+	sb.WriteString(level.String())
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&sb, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&sb, " %v", keysAndValues[i])
+		}
+	}
+
+	l.logger.Print(sb.String())
+}
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return "SILENT"
+	}
+}
+
+type operationStateKey struct{}
+
+// operationState is attached to the context of every model operation so the
+// command monitor can tell a retried command from the first attempt.
+type operationState struct {
+	failed int32
+}
+
+func withOperationState(ctx context.Context) context.Context {
+	return context.WithValue(ctx, operationStateKey{}, &operationState{})
+}
+
+// It builds the command monitor that reports retries, failures and slow
+// commands through the client logger.
+func (c *Client) commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			state, ok := ctx.Value(operationStateKey{}).(*operationState)
+			if ok && atomic.SwapInt32(&state.failed, 0) == 1 {
+				c.currentLogger().Log(ctx, LogLevelWarn, "retrying command",
+					"command", evt.CommandName,
+					"database", evt.DatabaseName,
+					"request_id", evt.RequestID,
+				)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			duration := time.Duration(evt.DurationNanos)
+			if c.slowThreshold > 0 && duration >= c.slowThreshold {
+				c.currentLogger().Log(ctx, LogLevelWarn, "slow command",
+					"command", evt.CommandName,
+					"duration", duration,
+					"request_id", evt.RequestID,
+				)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			if state, ok := ctx.Value(operationStateKey{}).(*operationState); ok {
+				atomic.StoreInt32(&state.failed, 1)
+			}
+			level := LogLevelError
+			if expectedFailure(evt.Failure) {
+				level = LogLevelDebug
+			}
+			c.currentLogger().Log(ctx, level, "command failed",
+				"command", evt.CommandName,
+				"duration", time.Duration(evt.DurationNanos),
+				"request_id", evt.RequestID,
+				"failure", evt.Failure,
+			)
+		},
+	}
+}
+
+// The server errors yamgo handles itself, e.g. ApplySchema creating a missing
+// collection, are only logged at debug level.
+var expectedFailures = []string{
+	"(NamespaceNotFound)",
+}
+
+// It reports whether a command failure is one yamgo expects. The failure is
+// the message of the driver error, which starts with the error code name.
+func expectedFailure(failure string) bool {
+	for _, prefix := range expectedFailures {
+		if strings.HasPrefix(failure, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loggerValue wraps the client logger, as atomic.Value needs a single
+// concrete type.
+type loggerValue struct {
+	Logger
+}
+
+// SetLogger replaces the client logger, dropping records below level. It is
+// safe to call while operations are running.
+func (c *Client) SetLogger(logger Logger, level LogLevel) {
+	c.logger.Store(loggerValue{newLeveledLogger(logger, level)})
+}
+
+func (c *Client) currentLogger() Logger {
+	if value, ok := c.logger.Load().(loggerValue); ok {
+		return value.Logger
+	}
+
+	return noopLogger{}
+}
+
+func (mf *Model) logger() Logger {
+	if mf.client == nil {
+		return noopLogger{}
+	}
+
+	return mf.client.currentLogger()
+}
+
+func (mf *Model) logCursorError(ctx context.Context, cursor string, err error) {
+	mf.logger().Log(ctx, LogLevelWarn, "invalid pagination cursor",
+		"collection", mf.col.Name(),
+		"cursor", cursor,
+		"error", err,
+	)
+}
@@ -0,0 +1,108 @@
+package yamgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type (
+	// Operation describes a model operation to its middleware. Middleware can
+	// change the fields before calling next, and the operation runs with the
+	// changed values.
+	Operation struct {
+		Kind       OperationKind
+		Collection string
+		Filter     bson.M
+		// Update of UpdateOne, UpdateMany and FindOneAndUpdate.
+		Update interface{}
+		// Document of InsertOne, or the replacement of ReplaceOne and
+		// FindOneAndReplace.
+		Document interface{}
+		// Documents of InsertMany.
+		Documents []interface{}
+		// Writes of Bulk.Execute, already prepared by the bulk builder.
+		Writes []mongo.WriteModel
+		// Pipeline of Aggregate, AggregateEach and PaginatedAggregate.
+		Pipeline interface{}
+		// Populate of FindAndPopulate and FindAndPopulateEach.
+		Populate []PopulateOptions
+		// Options of the operation, of the type the method accepts, e.g.
+		// []*options.CountOptions for CountDocuments or PaginationFindParams
+		// for PaginatedFind. Bulk.Execute passes whether it is ordered.
+		Options interface{}
+		// Result is the value reads decode into. Once next returns it holds
+		// the result of the other operations, e.g. the count of
+		// CountDocuments.
+		Result interface{}
+		// Page of PaginatedFind and PaginatedAggregate, set once next
+		// returns. Middleware short circuiting them must set it.
+		Page *Page
+	}
+
+	// Handler runs an operation.
+	Handler func(ctx context.Context, op *Operation) error
+
+	// Middleware wraps the operations of a model. It calls next to run the
+	// operation, or returns without calling it to short circuit it. The
+	// context passed to next is the one the operation runs with.
+	Middleware func(ctx context.Context, op *Operation, next Handler) error
+)
+
+// Use adds middleware around the operations of the model, after the ones of
+// the client. The first middleware is the outermost.
+func (mf *Model) Use(middleware ...Middleware) {
+	mf.middleware = append(append([]Middleware{}, mf.middleware...), middleware...)
+}
+
+// Use adds middleware around the operations of every model of the client,
+// including the ones already created.
+func (c *Client) Use(middleware ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.middleware = append(append([]Middleware{}, c.middleware...), middleware...)
+}
+
+func (c *Client) middlewareChain() []Middleware {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.middleware
+}
+
+// Use adds middleware to the default client.
+func Use(middleware ...Middleware) {
+	_client.Use(middleware...)
+}
+
+// It runs an operation through the client and model middleware. The handler
+// gets a copy of the model bound to the context middleware passed along.
+func (mf *Model) run(op *Operation, handler func(model *Model, op *Operation) error) error {
+	op.Collection = mf.col.Name()
+
+	chain := mf.middleware
+	if mf.client != nil {
+		if middleware := mf.client.middlewareChain(); len(middleware) > 0 {
+			chain = append(append([]Middleware{}, middleware...), chain...)
+		}
+	}
+
+	if len(chain) == 0 {
+		return handler(mf, op)
+	}
+
+	next := Handler(func(ctx context.Context, op *Operation) error {
+		return handler(mf.WithContext(ctx), op)
+	})
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		middleware, inner := chain[i], next
+		next = func(ctx context.Context, op *Operation) error {
+			return middleware(ctx, op, inner)
+		}
+	}
+
+	return next(mf.context(), op)
+}
@@ -0,0 +1,146 @@
+package yamgo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindOneAndUpdate atomically updates a document and decodes it into result,
+// as it was before the update unless options.After is requested.
+func (mf *Model) FindOneAndUpdate(filter bson.M, update interface{}, result interface{}, opts ...*options.FindOneAndUpdateOptions) error {
+	op := &Operation{Kind: OperationFindOneAndUpdate, Filter: filter, Update: update, Options: opts, Result: result}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		opts, _ := op.Options.([]*options.FindOneAndUpdateOptions)
+		return model.findOneAndUpdate(op.Filter, op.Update, op.Result, opts...)
+	})
+}
+
+func (mf *Model) findOneAndUpdate(filter bson.M, update interface{}, result interface{}, opts ...*options.FindOneAndUpdateOptions) error {
+
+	ctx, cancel := mf.newContext(OperationFindOneAndUpdate)
+
+	defer cancel()
+
+	option := options.MergeFindOneAndUpdateOptions(opts...)
+	if option.MaxTime == nil {
+		option.MaxTime = maxTime(ctx)
+	}
+
+	err := mf.decodeSingleResult(mf.col.FindOneAndUpdate(ctx, mf.scope(filter), mf.prepareUpdate(update), option), result)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if conflict := mf.checkVersion(filter, false); conflict != nil {
+			return conflict
+		}
+	}
+
+	return err
+}
+
+// FindOneAndReplace atomically replaces a document and decodes it into
+// result, as it was before the replacement unless options.After is requested.
+func (mf *Model) FindOneAndReplace(filter bson.M, replacement interface{}, result interface{}, opts ...*options.FindOneAndReplaceOptions) error {
+	op := &Operation{Kind: OperationFindOneAndReplace, Filter: filter, Document: replacement, Options: opts, Result: result}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		opts, _ := op.Options.([]*options.FindOneAndReplaceOptions)
+		return model.findOneAndReplace(op.Filter, op.Document, op.Result, opts...)
+	})
+}
+
+func (mf *Model) findOneAndReplace(filter bson.M, replacement interface{}, result interface{}, opts ...*options.FindOneAndReplaceOptions) error {
+
+	replacement, err := mf.beforeUpdate(replacement)
+	if err != nil {
+		return err
+	}
+
+	option := options.MergeFindOneAndReplaceOptions(opts...)
+	replacement = mf.stampDocument(replacement, option.Upsert != nil && *option.Upsert)
+
+	if err = mf.validate(replacement); err != nil {
+		return err
+	}
+
+	ctx, cancel := mf.newContext(OperationFindOneAndReplace)
+
+	defer cancel()
+
+	if option.MaxTime == nil {
+		option.MaxTime = maxTime(ctx)
+	}
+
+	version, replacement, restore, versioned := mf.bumpVersion(replacement)
+	query := filter
+	if versioned {
+		query = mf.versionFilter(filter, version)
+	}
+
+	err = mf.decodeSingleResult(mf.col.FindOneAndReplace(ctx, mf.scope(query), replacement, option), result)
+
+	if versioned && err != nil {
+		restore()
+		if errors.Is(err, mongo.ErrNoDocuments) || mongo.IsDuplicateKeyError(err) {
+			if conflict := mf.versionConflict(filter, version); conflict != nil {
+				return conflict
+			}
+		}
+	}
+
+	return err
+}
+
+// FindOneAndDelete atomically deletes a document and decodes it into result.
+// On soft deleting models the document is marked as deleted instead.
+func (mf *Model) FindOneAndDelete(filter bson.M, result interface{}, opts ...*options.FindOneAndDeleteOptions) error {
+	op := &Operation{Kind: OperationFindOneAndDelete, Filter: filter, Options: opts, Result: result}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		opts, _ := op.Options.([]*options.FindOneAndDeleteOptions)
+		return model.findOneAndDelete(op.Filter, op.Result, opts...)
+	})
+}
+
+func (mf *Model) findOneAndDelete(filter bson.M, result interface{}, opts ...*options.FindOneAndDeleteOptions) error {
+
+	if mf.deletedField != "" {
+		option := options.MergeFindOneAndDeleteOptions(opts...)
+		model := *mf
+		model.deletedScope = excludeDeleted
+		return model.findOneAndUpdate(filter, model.softDeleteUpdate(), result, &options.FindOneAndUpdateOptions{
+			Collation:  option.Collation,
+			Hint:       option.Hint,
+			MaxTime:    option.MaxTime,
+			Projection: option.Projection,
+			Sort:       option.Sort,
+		})
+	}
+
+	ctx, cancel := mf.newContext(OperationFindOneAndDelete)
+
+	defer cancel()
+
+	option := options.MergeFindOneAndDeleteOptions(opts...)
+	if option.MaxTime == nil {
+		option.MaxTime = maxTime(ctx)
+	}
+
+	return mf.decodeSingleResult(mf.col.FindOneAndDelete(ctx, mf.scope(filter), option), result)
+}
+
+func (mf *Model) decodeSingleResult(res *mongo.SingleResult, result interface{}) error {
+
+	if res.Err() != nil {
+		return res.Err()
+	}
+
+	if err := res.Decode(result); err != nil {
+		return err
+	}
+
+	return mf.afterFind(result)
+}
@@ -0,0 +1,177 @@
+package yamgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var objectIDType = reflect.TypeOf(primitive.ObjectID{})
+
+// ReplaceOne replaces the first document matching filter. On versioned
+// models it only matches the version of the replacement, which is then
+// incremented, and fails with ErrVersionConflict on a stale version.
+func (mf *Model) ReplaceOne(filter bson.M, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	op := &Operation{Kind: OperationReplaceOne, Filter: filter, Document: replacement, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.ReplaceOptions)
+		op.Result, err = model.replaceOne(op.Filter, op.Document, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.UpdateResult)
+
+	return res, err
+}
+
+func (mf *Model) replaceOne(filter bson.M, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+
+	replacement, err := mf.beforeUpdate(replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	upsert := options.MergeReplaceOptions(opts...).Upsert
+	replacement = mf.stampDocument(replacement, upsert != nil && *upsert)
+
+	if err = mf.validate(replacement); err != nil {
+		return nil, err
+	}
+
+	version, replacement, restore, versioned := mf.bumpVersion(replacement)
+	query := filter
+	if versioned {
+		query = mf.versionFilter(filter, version)
+	}
+
+	ctx, cancel := mf.newContext(OperationReplaceOne)
+
+	defer cancel()
+
+	res, err := mf.col.ReplaceOne(ctx, mf.scope(query), replacement, opts...)
+
+	if versioned && (err != nil || res.MatchedCount+res.UpsertedCount == 0) {
+		restore()
+		// A stale upsert collides with the stored document on _id.
+		if err == nil || mongo.IsDuplicateKeyError(err) {
+			if conflict := mf.versionConflict(filter, version); conflict != nil {
+				return nil, conflict
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Save inserts the document when its _id is empty, generating a new ObjectID,
+// and replaces or upserts it by _id otherwise. It returns the final _id.
+// The document must be a pointer to a struct, or a map with string keys,
+// e.g. bson.M, whose values can hold an ObjectID.
+func (mf *Model) Save(doc interface{}) (interface{}, error) {
+
+	id, generated, err := ensureDocumentID(doc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if generated {
+		if _, err = mf.InsertOne(doc); err != nil {
+			return nil, err
+		}
+		return id, nil
+	}
+
+	_, err = mf.ReplaceOne(bson.M{"_id": id}, doc, options.Replace().SetUpsert(true))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// It reads the _id of a document and sets a new ObjectID when it is empty.
+func ensureDocumentID(doc interface{}) (id interface{}, generated bool, err error) {
+
+	val := reflect.ValueOf(doc)
+	if val.Kind() == reflect.Ptr && !val.IsNil() && val.Elem().Kind() == reflect.Map {
+		val = val.Elem()
+	}
+
+	if val.Kind() == reflect.Map {
+		return ensureMapID(val)
+	}
+
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return nil, false, errors.New("document must be a pointer")
+	}
+
+	val, err = documentStruct(doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	field, ok := fieldByBSONKey(val, "_id")
+	if !ok {
+		return nil, false, errors.New("document has no _id field")
+	}
+
+	if !field.IsZero() {
+		return field.Interface(), false, nil
+	}
+
+	newID := primitive.NewObjectID()
+
+	switch {
+	case field.Type() == objectIDType:
+		field.Set(reflect.ValueOf(newID))
+	case field.Kind() == reflect.Interface:
+		field.Set(reflect.ValueOf(newID))
+	default:
+		return nil, false, fmt.Errorf("cannot generate an _id of type %s", field.Type())
+	}
+
+	return newID, true, nil
+}
+
+// It is ensureDocumentID for maps with string keys.
+func ensureMapID(m reflect.Value) (id interface{}, generated bool, err error) {
+
+	if m.Type().Key().Kind() != reflect.String {
+		return nil, false, fmt.Errorf("document map keys must be strings, not %s", m.Type().Key())
+	}
+
+	if m.IsNil() {
+		return nil, false, errors.New("document must not be a nil map")
+	}
+
+	key := reflect.ValueOf("_id").Convert(m.Type().Key())
+
+	value := m.MapIndex(key)
+	if value.IsValid() && value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if value.IsValid() && !value.IsZero() {
+		return value.Interface(), false, nil
+	}
+
+	newID := reflect.ValueOf(primitive.NewObjectID())
+	if !newID.Type().AssignableTo(m.Type().Elem()) {
+		return nil, false, fmt.Errorf("cannot generate an _id of type %s", m.Type().Elem())
+	}
+
+	m.SetMapIndex(key, newID)
+
+	return newID.Interface(), true, nil
+}
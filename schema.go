@@ -0,0 +1,354 @@
+package yamgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ValidationLevelStrict   = "strict"
+	ValidationLevelModerate = "moderate"
+	ValidationLevelOff      = "off"
+
+	ValidationActionError = "error"
+	ValidationActionWarn  = "warn"
+)
+
+// The server error code for a missing collection.
+const namespaceNotFound = 26
+
+var (
+	bsonMType      = reflect.TypeOf(bson.M{})
+	bsonDType      = reflect.TypeOf(bson.D{})
+	bytesType      = reflect.TypeOf([]byte{})
+	decimalType    = reflect.TypeOf(primitive.Decimal128{})
+	binaryType     = reflect.TypeOf(primitive.Binary{})
+	timestampType  = reflect.TypeOf(primitive.Timestamp{})
+	regexType      = reflect.TypeOf(primitive.Regex{})
+	rawDocType     = reflect.TypeOf(bson.Raw{})
+	interfaceType  = reflect.TypeOf((*interface{})(nil)).Elem()
+	primitiveTypes = map[reflect.Type]string{
+		objectIDType:  "objectId",
+		timeType:      "date",
+		dateTimeType:  "date",
+		decimalType:   "decimal",
+		binaryType:    "binData",
+		bytesType:     "binData",
+		timestampType: "timestamp",
+		regexType:     "regex",
+		bsonMType:     "object",
+		bsonDType:     "object",
+		rawDocType:    "object",
+	}
+)
+
+// WithSchema sets the struct ApplySchema turns into the collection validator.
+func WithSchema(schema interface{}) ModelOption {
+	return func(mf *Model) {
+		mf.schema = schema
+	}
+}
+
+// WithValidationLevel sets which documents the collection validator checks:
+// ValidationLevelStrict, the default, ValidationLevelModerate or
+// ValidationLevelOff.
+func WithValidationLevel(level string) ModelOption {
+	return func(mf *Model) {
+		mf.validationLevel = level
+	}
+}
+
+// WithValidationAction sets whether invalid documents are rejected,
+// ValidationActionError, the default, or only logged, ValidationActionWarn.
+func WithValidationAction(action string) ModelOption {
+	return func(mf *Model) {
+		mf.validationAction = action
+	}
+}
+
+// JSONSchema reflects a struct into a $jsonSchema document, following the
+// bson tags of its fields. Fields are required unless they are pointers or
+// omitempty, pointers, slices and maps also accept null. The validate tags
+// add the matching keywords, e.g. min=3 on a string becomes minLength.
+func JSONSchema(schema interface{}) (bson.M, error) {
+	typ := reflect.TypeOf(schema)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, errors.New("schema must be a struct")
+	}
+
+	return structSchema(typ, map[reflect.Type]bool{})
+}
+
+// ApplySchema sets the $jsonSchema of the model schema as the validator of
+// the collection, creating the collection when it does not exist yet.
+func (mf *Model) ApplySchema() error {
+
+	if mf.schema == nil {
+		return errors.New("model has no schema")
+	}
+
+	schema, err := JSONSchema(mf.schema)
+	if err != nil {
+		return err
+	}
+
+	level, action := mf.validationLevel, mf.validationAction
+	if level == "" {
+		level = ValidationLevelStrict
+	}
+	if action == "" {
+		action = ValidationActionError
+	}
+
+	validator := bson.M{"$jsonSchema": schema}
+
+	ctx, cancel := mf.newContext(OperationCollMod)
+
+	defer cancel()
+
+	err = mf.col.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: mf.col.Name()},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: level},
+		{Key: "validationAction", Value: action},
+	}).Err()
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == namespaceNotFound {
+		return mf.col.Database().CreateCollection(ctx, mf.col.Name(), options.CreateCollection().
+			SetValidator(validator).
+			SetValidationLevel(level).
+			SetValidationAction(action))
+	}
+
+	return err
+}
+
+// It builds the object schema of a struct. Types already being visited are
+// recursive and only checked to be objects.
+func structSchema(typ reflect.Type, visiting map[reflect.Type]bool) (bson.M, error) {
+	if visiting[typ] {
+		return bson.M{"bsonType": "object"}, nil
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	properties := bson.M{}
+	required := []string{}
+
+	if err := addProperties(typ, properties, &required, visiting); err != nil {
+		return nil, err
+	}
+
+	schema := bson.M{"bsonType": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema, nil
+}
+
+func addProperties(typ reflect.Type, properties bson.M, required *[]string, visiting map[reflect.Type]bool) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("bson") == "-" {
+			continue
+		}
+
+		key, inline := bsonKey(field)
+		if inline && field.Type.Kind() == reflect.Struct {
+			if err := addProperties(field.Type, properties, required, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		property, err := typeSchema(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+
+		rules := splitRules(field.Tag.Get("validate"))
+		if err = addRuleKeywords(property, field.Type, rules); err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+
+		if !omitEmpty(field) || hasRule(field.Tag.Get("validate"), "required") {
+			*required = append(*required, key)
+		}
+
+		properties[key] = property
+	}
+
+	return nil
+}
+
+// It maps a Go type to the schema of the values the bson codec encodes it to.
+func typeSchema(typ reflect.Type, visiting map[reflect.Type]bool) (bson.M, error) {
+	nullable := false
+	for typ.Kind() == reflect.Ptr {
+		nullable = true
+		typ = typ.Elem()
+	}
+
+	schema := bson.M{}
+
+	if bsonType, ok := primitiveTypes[typ]; ok {
+		// Nil byte slices and maps are encoded as null as well.
+		nullable = nullable || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map
+		schema["bsonType"] = bsonType
+		return withNull(schema, nullable), nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		// Anything goes.
+		return schema, nil
+	case reflect.String:
+		schema["bsonType"] = "string"
+	case reflect.Bool:
+		schema["bsonType"] = "bool"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		schema["bsonType"] = "int"
+	case reflect.Int64:
+		schema["bsonType"] = "long"
+	case reflect.Int, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		// Encoded as int32 when the value fits.
+		schema["bsonType"] = bson.A{"int", "long"}
+	case reflect.Float32, reflect.Float64:
+		schema["bsonType"] = "double"
+	case reflect.Struct:
+		object, err := structSchema(typ, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return withNull(object, nullable), nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", typ.Key())
+		}
+		schema["bsonType"] = "object"
+		if typ.Elem() != interfaceType {
+			values, err := typeSchema(typ.Elem(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			schema["additionalProperties"] = values
+		}
+		nullable = true
+	case reflect.Slice, reflect.Array:
+		schema["bsonType"] = "array"
+		if typ.Elem() != interfaceType {
+			items, err := typeSchema(typ.Elem(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			schema["items"] = items
+		}
+		nullable = nullable || typ.Kind() == reflect.Slice
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+
+	return withNull(schema, nullable), nil
+}
+
+func withNull(schema bson.M, nullable bool) bson.M {
+	if !nullable {
+		return schema
+	}
+
+	switch bsonType := schema["bsonType"].(type) {
+	case string:
+		schema["bsonType"] = bson.A{bsonType, "null"}
+	case bson.A:
+		schema["bsonType"] = append(bsonType, "null")
+	}
+
+	return schema
+}
+
+// It translates the validate rules of a field into schema keywords.
+func addRuleKeywords(schema bson.M, typ reflect.Type, rules []string) error {
+	nullable := typ.Kind() == reflect.Ptr
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "min", "max", "len":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s rule %q", name, param)
+			}
+
+			prefix := ""
+			switch typ.Kind() {
+			case reflect.String:
+				prefix = "Length"
+			case reflect.Slice, reflect.Array:
+				prefix = "Items"
+			case reflect.Map:
+				prefix = "Properties"
+			}
+
+			switch {
+			case prefix == "" && name == "min":
+				schema["minimum"] = limit
+			case prefix == "" && name == "max":
+				schema["maximum"] = limit
+			case name == "len":
+				schema["min"+prefix] = int64(limit)
+				schema["max"+prefix] = int64(limit)
+			default:
+				schema[name+prefix] = int64(limit)
+			}
+		case "enum":
+			if typ.Kind() != reflect.String {
+				continue
+			}
+			values := bson.A{}
+			for _, value := range strings.Split(param, "|") {
+				values = append(values, value)
+			}
+			if nullable {
+				values = append(values, nil)
+			}
+			schema["enum"] = values
+		case "regex":
+			schema["pattern"] = param
+		}
+	}
+
+	return nil
+}
+
+func omitEmpty(field reflect.StructField) bool {
+	if field.Type.Kind() == reflect.Ptr {
+		return true
+	}
+
+	parts := strings.Split(field.Tag.Get("bson"), ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+//go:build go1.21
+
+package yamgo
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger adapts a log/slog logger.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+}
+
+func (l slogLogger) Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	l.logger.Log(ctx, slogLevel(level), msg, keysAndValues...)
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
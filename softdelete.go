@@ -0,0 +1,174 @@
+package yamgo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const DefaultDeletedAtField = "deletedAt"
+
+type deletedScope int
+
+const (
+	excludeDeleted deletedScope = iota
+	includeDeleted
+	onlyDeleted
+)
+
+// WithSoftDelete makes deletes set the deletedAt field instead of removing
+// documents, and hides the deleted documents from reads and writes.
+func WithSoftDelete() ModelOption {
+	return WithSoftDeleteField(DefaultDeletedAtField)
+}
+
+// WithSoftDeleteField is like WithSoftDelete with a custom field name.
+func WithSoftDeleteField(field string) ModelOption {
+	return func(mf *Model) {
+		mf.deletedField = field
+	}
+}
+
+// WithSoftDeletedLookup makes the populate lookups into collection skip the
+// documents where field is set, as a soft deleting model of that collection
+// would.
+func WithSoftDeletedLookup(collection string, field string) ModelOption {
+	return func(mf *Model) {
+		lookupDeleted := make(map[string]string, len(mf.lookupDeleted)+1)
+		for k, v := range mf.lookupDeleted {
+			lookupDeleted[k] = v
+		}
+		lookupDeleted[collection] = field
+		mf.lookupDeleted = lookupDeleted
+	}
+}
+
+// WithDeleted returns a copy of the model whose operations also see the
+// soft deleted documents.
+func (mf *Model) WithDeleted() *Model {
+	model := *mf
+	model.deletedScope = includeDeleted
+	return &model
+}
+
+// OnlyDeleted returns a copy of the model whose operations only see the soft
+// deleted documents.
+func (mf *Model) OnlyDeleted() *Model {
+	model := *mf
+	model.deletedScope = onlyDeleted
+	return &model
+}
+
+// ErrNotSoftDeleting is returned by Restore on models without soft deletes.
+var ErrNotSoftDeleting = errors.New("model does not soft delete")
+
+// Restore clears the deletion mark of the soft deleted documents matching
+// filter.
+func (mf *Model) Restore(filter bson.M) (*mongo.UpdateResult, error) {
+	if mf.deletedField == "" {
+		return nil, ErrNotSoftDeleting
+	}
+	return mf.OnlyDeleted().UpdateMany(filter, bson.M{"$unset": bson.M{mf.deletedField: ""}})
+}
+
+// HardDelete permanently removes every document matching filter, soft
+// deleted or not.
+func (mf *Model) HardDelete(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	op := &Operation{Kind: OperationDeleteMany, Filter: filter, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.DeleteOptions)
+		op.Result, err = model.hardDelete(op.Filter, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.DeleteResult)
+
+	return res, err
+}
+
+func (mf *Model) hardDelete(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+
+	ctx, cancel := mf.newContext(OperationDeleteMany)
+
+	defer cancel()
+
+	res, err := mf.col.DeleteMany(ctx, filter, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// It restricts a filter to the documents visible in the model scope.
+func (mf *Model) scope(filter bson.M) bson.M {
+	if mf.deletedField == "" || mf.deletedScope == includeDeleted {
+		return filter
+	}
+
+	condition := bson.M{mf.deletedField: nil}
+	if mf.deletedScope == onlyDeleted {
+		condition = bson.M{mf.deletedField: bson.M{"$ne": nil}}
+	}
+
+	if len(filter) == 0 {
+		return condition
+	}
+
+	return bson.M{"$and": []bson.M{filter, condition}}
+}
+
+// It marks the documents as deleted, reporting them as a delete would.
+func (mf *Model) softDelete(filter bson.M, many bool, opts []*options.DeleteOptions) (*mongo.DeleteResult, error) {
+
+	model := *mf
+	model.deletedScope = excludeDeleted
+
+	option := options.MergeDeleteOptions(opts...)
+	updateOptions := &options.UpdateOptions{Collation: option.Collation, Hint: option.Hint}
+	update := model.softDeleteUpdate()
+
+	var res *mongo.UpdateResult
+	var err error
+	if many {
+		res, err = model.updateMany(filter, update, updateOptions)
+	} else {
+		res, err = model.updateOne(filter, update, updateOptions)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongo.DeleteResult{DeletedCount: res.ModifiedCount}, nil
+}
+
+func (mf *Model) softDeleteUpdate() bson.M {
+	return bson.M{"$set": bson.M{mf.deletedField: mf.now()}}
+}
+
+// It fills in the soft delete field of the populated collections declared
+// with WithSoftDeletedLookup, or of the model collection itself, and lets the
+// lookups see the deleted documents when the model scope does.
+func (mf *Model) resolvePopulate(populate []PopulateOptions) []PopulateOptions {
+	resolved := make([]PopulateOptions, len(populate))
+	for i, value := range populate {
+		if value.SoftDeleteField == "" {
+			if field, ok := mf.lookupDeleted[value.Collection]; ok {
+				value.SoftDeleteField = field
+			} else if value.Collection == mf.col.Name() {
+				value.SoftDeleteField = mf.deletedField
+			}
+		}
+		if mf.deletedScope != excludeDeleted {
+			value.WithDeleted = true
+		}
+		resolved[i] = value
+	}
+
+	return resolved
+}
@@ -0,0 +1,209 @@
+package yamgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// It is returned by the iteration callbacks to stop early without error.
+var errStopIteration = errors.New("stop iteration")
+
+// Stream delivers documents through a buffered channel. The producer blocks
+// while the buffer is full, so a slow consumer slows the cursor down instead
+// of piling documents up in memory.
+type Stream struct {
+	docs   chan bson.Raw
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// FindEach calls fn for every document matching filter, reading the cursor
+// one batch at a time. Iteration stops at the first error returned by fn.
+// The document is only valid until fn returns; copy it to retain it.
+func (mf *Model) FindEach(filter bson.M, fn func(doc bson.Raw) error, opts ...*options.FindOptions) error {
+	op := &Operation{Kind: OperationFindEach, Filter: filter, Options: opts}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		opts, _ := op.Options.([]*options.FindOptions)
+		return model.findEach(op.Filter, fn, opts...)
+	})
+}
+
+func (mf *Model) findEach(filter bson.M, fn func(doc bson.Raw) error, opts ...*options.FindOptions) error {
+
+	ctx, cancel := mf.newContext(OperationFindEach)
+
+	defer cancel()
+
+	cur, err := mf.col.Find(ctx, mf.scope(filter), opts...)
+	if err != nil {
+		return err
+	}
+
+	return eachDocument(ctx, cur, fn)
+}
+
+// AggregateEach is the streaming counterpart of Aggregate.
+func (mf *Model) AggregateEach(pipeline mongo.Pipeline, fn func(doc bson.Raw) error, opts ...*options.AggregateOptions) error {
+	op := &Operation{Kind: OperationAggregateEach, Pipeline: pipeline, Options: opts}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		pipeline, _ := op.Pipeline.(mongo.Pipeline)
+		opts, _ := op.Options.([]*options.AggregateOptions)
+		return model.aggregateEach(pipeline, fn, opts...)
+	})
+}
+
+func (mf *Model) aggregateEach(pipeline mongo.Pipeline, fn func(doc bson.Raw) error, opts ...*options.AggregateOptions) error {
+
+	ctx, cancel := mf.newContext(OperationAggregateEach)
+
+	defer cancel()
+
+	cur, err := mf.col.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return err
+	}
+
+	return eachDocument(ctx, cur, fn)
+}
+
+// FindAndPopulateEach is the streaming counterpart of FindAndPopulate. Unlike
+// FindAndPopulate it does not apply a default limit.
+func (mf *Model) FindAndPopulateEach(filter bson.M, option options.FindOptions, populate []PopulateOptions, fn func(doc bson.Raw) error) error {
+	op := &Operation{Kind: OperationFindEach, Filter: filter, Populate: populate, Options: option}
+
+	return mf.run(op, func(model *Model, op *Operation) error {
+		option, _ := op.Options.(options.FindOptions)
+
+		pipeline := model.buildPopulatePipeline(op.Filter, option, op.Populate, 0)
+
+		aggregateOptions := options.Aggregate()
+		if option.BatchSize != nil {
+			aggregateOptions.SetBatchSize(*option.BatchSize)
+		}
+
+		return model.aggregateEach(pipeline, fn, aggregateOptions)
+	})
+}
+
+// FindStream starts reading the documents matching filter in the background.
+// The stream must be closed, which also stops the cursor early. Middleware
+// only wraps the start of the stream, and the context it passes to next must
+// outlive it. A middleware failure is returned by Err and Close.
+func (mf *Model) FindStream(filter bson.M, buffer int, opts ...*options.FindOptions) *Stream {
+	op := &Operation{Kind: OperationFindStream, Filter: filter, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) error {
+		opts, _ := op.Options.([]*options.FindOptions)
+		op.Result = model.findStream(op.Filter, buffer, opts...)
+		return nil
+	})
+
+	stream, ok := op.Result.(*Stream)
+	if ok && err == nil {
+		return stream
+	}
+
+	if ok {
+		stream.Close()
+	} else if err == nil {
+		err = fmt.Errorf("%s returned no stream", op.Kind)
+	}
+
+	return failedStream(err)
+}
+
+func (mf *Model) findStream(filter bson.M, buffer int, opts ...*options.FindOptions) *Stream {
+
+	ctx, cancel := mf.newContext(OperationFindStream)
+
+	stream := &Stream{
+		docs:   make(chan bson.Raw, buffer),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(stream.done)
+		defer close(stream.docs)
+
+		cur, err := mf.col.Find(ctx, mf.scope(filter), opts...)
+		if err != nil {
+			stream.err = err
+			return
+		}
+
+		stream.err = eachDocument(ctx, cur, func(doc bson.Raw) error {
+			select {
+			case stream.docs <- append(bson.Raw(nil), doc...):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return stream
+}
+
+// It returns an already ended stream reporting err.
+func failedStream(err error) *Stream {
+	stream := &Stream{
+		docs:   make(chan bson.Raw),
+		cancel: func() {},
+		done:   make(chan struct{}),
+		err:    err,
+	}
+	close(stream.docs)
+	close(stream.done)
+
+	return stream
+}
+
+// Documents returns the channel of documents. It is closed when the cursor
+// is exhausted, fails or the stream is closed.
+func (s *Stream) Documents() <-chan bson.Raw {
+	return s.docs
+}
+
+// Err returns the error that ended the stream, once Documents is closed.
+func (s *Stream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Close stops the stream and releases the cursor.
+func (s *Stream) Close() error {
+	s.cancel()
+
+	for range s.docs {
+	}
+
+	<-s.done
+
+	if errors.Is(s.err, context.Canceled) {
+		return nil
+	}
+
+	return s.err
+}
+
+func eachDocument(ctx context.Context, cur *mongo.Cursor, fn func(doc bson.Raw) error) error {
+
+	defer cur.Close(context.Background())
+
+	for cur.Next(ctx) {
+		if err := fn(cur.Current); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
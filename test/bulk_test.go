@@ -0,0 +1,93 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBulkExecute(t *testing.T) {
+	fooModel := models.FooModel()
+
+	foo1 := models.FooSchema{ID: primitive.NewObjectID(), Item: "a"}
+	foo2 := models.FooSchema{ID: primitive.NewObjectID(), Item: "b"}
+	upsertID := primitive.NewObjectID()
+
+	result, err := fooModel.Bulk().
+		Insert(foo1, foo2).
+		UpdateOne(bson.M{"_id": foo1.ID}, bson.M{"$set": bson.M{"item": "c"}}).
+		UpsertOne(bson.M{"_id": upsertID}, bson.M{"$set": bson.M{"item": "d"}}).
+		DeleteOne(bson.M{"_id": foo2.ID}).
+		Execute()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), result.InsertedCount)
+	assert.Equal(t, int64(1), result.ModifiedCount)
+	assert.Equal(t, int64(1), result.UpsertedCount)
+	assert.Equal(t, int64(1), result.DeletedCount)
+	assert.Equal(t, upsertID, result.UpsertedIDs[3])
+	assert.Equal(t, 5, result.Executed)
+	assert.Empty(t, result.FailedIndexes())
+	assert.Equal(t, []yamgo.WriteStatus{
+		yamgo.WriteSucceeded, yamgo.WriteSucceeded, yamgo.WriteSucceeded, yamgo.WriteSucceeded, yamgo.WriteSucceeded,
+	}, result.Statuses)
+
+	DropCollection("foos")
+}
+
+func TestBulkEmpty(t *testing.T) {
+	fooModel := models.FooModel()
+
+	_, err := fooModel.Bulk().Execute()
+
+	assert.Error(t, err)
+}
+
+func TestBulkUnorderedChunks(t *testing.T) {
+	fooModel := models.FooModel()
+	duplicate := models.FooSchema{ID: primitive.NewObjectID()}
+
+	bulk := fooModel.Bulk().Ordered(false).ChunkSize(2)
+	bulk.Insert(duplicate, models.FooSchema{ID: primitive.NewObjectID()})
+	bulk.Insert(duplicate, models.FooSchema{ID: primitive.NewObjectID()})
+	bulk.Insert(duplicate, models.FooSchema{ID: primitive.NewObjectID()})
+
+	result, err := bulk.Execute()
+
+	var bulkErr *yamgo.BulkWriteError
+	assert.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, int64(4), result.InsertedCount)
+	assert.Equal(t, 6, result.Executed)
+	assert.Equal(t, []int{2, 4}, result.FailedIndexes())
+	assert.Equal(t, yamgo.WriteFailed, result.Statuses[2])
+	assert.Equal(t, yamgo.WriteSucceeded, result.Statuses[3])
+	assert.True(t, result.Failures[0].IsDuplicateKey())
+
+	DropCollection("foos")
+}
+
+func TestBulkOrderedStopsOnFailure(t *testing.T) {
+	fooModel := models.FooModel()
+	duplicate := models.FooSchema{ID: primitive.NewObjectID()}
+
+	result, err := fooModel.Bulk().
+		ChunkSize(3).
+		Insert(duplicate, duplicate, models.FooSchema{ID: primitive.NewObjectID()}).
+		Insert(models.FooSchema{ID: primitive.NewObjectID()}).
+		Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), result.InsertedCount)
+	// The failed write is counted, the rest of its chunk is not.
+	assert.Equal(t, 2, result.Executed)
+	assert.Equal(t, []int{1}, result.FailedIndexes())
+	assert.Equal(t, []yamgo.WriteStatus{
+		yamgo.WriteSucceeded, yamgo.WriteFailed, yamgo.WriteNotExecuted, yamgo.WriteNotExecuted,
+	}, result.Statuses)
+
+	DropCollection("foos")
+}
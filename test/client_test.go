@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+func TestNewClientMissingParams(t *testing.T) {
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{})
+
+	assert.Error(t, err)
+	assert.Nil(t, client)
+}
+
+func TestNewClientIsolatedDatabase(t *testing.T) {
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "other",
+	})
+	assert.Nil(t, err)
+
+	item := models.ItemSchema{ID: primitive.NewObjectID()}
+	otherModel := client.NewModel("items")
+
+	_, err = otherModel.InsertOne(&item)
+	assert.Nil(t, err)
+
+	otherCount, err := otherModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, otherCount, 1)
+
+	itemModel := models.ItemModel()
+	count, err := itemModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, count, 0)
+
+	assert.Nil(t, client.Database.Drop(context.TODO()))
+	assert.Nil(t, client.Disconnect())
+}
+
+func TestNewClientAuthSourceWithoutCredentials(t *testing.T) {
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: "mongodb://localhost:27017",
+		DbName:        "test",
+		AuthSource:    "admin",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, client)
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	retryWrites := false
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl:          connectionURI,
+		DbName:                 "test",
+		AppName:                "yamgo-options-test",
+		MaxPoolSize:            10,
+		MinPoolSize:            1,
+		ReadPreference:         readpref.PrimaryPreferred(),
+		WriteConcern:           writeconcern.New(writeconcern.WMajority()),
+		RetryWrites:            &retryWrites,
+		ServerSelectionTimeout: 5 * time.Second,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, client.Database.Client().Ping(context.TODO(), nil))
+	assert.Nil(t, client.Disconnect())
+}
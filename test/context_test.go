@@ -0,0 +1,44 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWithContext(t *testing.T) {
+	item := models.ItemSchema{ID: primitive.NewObjectID()}
+	itemModel := models.ItemModel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err := itemModel.WithContext(ctx).InsertOne(&item)
+	assert.Nil(t, err)
+
+	result := models.ItemSchema{}
+	err = itemModel.WithContext(ctx).FindByObjectID(item.ID, &result)
+
+	assert.Nil(t, err)
+	assert.Equal(t, result.ID, item.ID)
+
+	DropCollection("items")
+}
+
+func TestWithCanceledContext(t *testing.T) {
+	itemModel := models.ItemModel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := []models.ItemSchema{}
+	err := itemModel.WithContext(ctx).Find(bson.M{}, &results)
+
+	assert.Error(t, err)
+	assert.Empty(t, results)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/wezard-it/yamgo/test/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestCountDocuments(t *testing.T) {
@@ -48,3 +49,95 @@ func TestCountDocumentsWithFilter(t *testing.T) {
 	DropCollection("items")
 
 }
+
+func TestCountDocumentsWithOptions(t *testing.T) {
+	itemModel := models.ItemModel()
+
+	items := []interface{}{
+		models.ItemSchema{ID: primitive.NewObjectID()},
+		models.ItemSchema{ID: primitive.NewObjectID()},
+		models.ItemSchema{ID: primitive.NewObjectID()},
+	}
+
+	_, err := itemModel.InsertMany(items)
+
+	assert.Nil(t, err)
+
+	result, err := itemModel.CountDocuments(bson.M{}, options.Count().SetLimit(2))
+
+	assert.Nil(t, err)
+	assert.Equal(t, result, 2)
+
+	result, err = itemModel.CountDocuments(bson.M{}, options.Count().SetSkip(1).SetHint(bson.M{"_id": 1}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, result, 2)
+
+	DropCollection("items")
+
+}
+
+func TestEstimatedDocumentCount(t *testing.T) {
+	itemModel := models.ItemModel()
+
+	items := []interface{}{models.ItemSchema{ID: primitive.NewObjectID()}, models.ItemSchema{ID: primitive.NewObjectID()}}
+
+	_, err := itemModel.InsertMany(items)
+
+	assert.Nil(t, err)
+
+	result, err := itemModel.EstimatedDocumentCount()
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, result, 2)
+
+	DropCollection("items")
+
+}
+
+func TestExists(t *testing.T) {
+	itemModel := models.ItemModel()
+
+	item := models.ItemSchema{ID: primitive.NewObjectID()}
+
+	_, err := itemModel.InsertOne(&item)
+
+	assert.Nil(t, err)
+
+	exists, err := itemModel.Exists(bson.M{"_id": item.ID})
+
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = itemModel.Exists(bson.M{"_id": primitive.NewObjectID()})
+
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	DropCollection("items")
+
+}
+
+func TestDistinct(t *testing.T) {
+	fooModel := models.FooModel()
+
+	foos := []interface{}{
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "a"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "b"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "a"},
+	}
+
+	_, err := fooModel.InsertMany(foos)
+
+	assert.Nil(t, err)
+
+	results := []string{}
+	err = fooModel.Distinct("item", bson.M{}, &results)
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, results)
+
+	DropCollection("foos")
+
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestDeleteOne(t *testing.T) {
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+	itemModel := models.ItemModel()
+
+	_, err := itemModel.InsertMany([]interface{}{item1, item2})
+	assert.Nil(t, err)
+
+	res, err := itemModel.DeleteOne(bson.M{"_id": item1.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.DeletedCount)
+
+	count, err := itemModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("items")
+}
+
+func TestDeleteMany(t *testing.T) {
+	fooModel := models.FooModel()
+
+	foos := []interface{}{
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "Done"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "done"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "todo"},
+	}
+	_, err := fooModel.InsertMany(foos)
+	assert.Nil(t, err)
+
+	collation := &options.Collation{Locale: "en", Strength: 2}
+	res, err := fooModel.DeleteMany(bson.M{"item": "done"}, options.Delete().SetCollation(collation))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.DeletedCount)
+
+	DropCollection("foos")
+}
+
+func TestDeleteByID(t *testing.T) {
+	item := models.ItemSchema{ID: primitive.NewObjectID()}
+	itemModel := models.ItemModel()
+
+	_, err := itemModel.InsertOne(&item)
+	assert.Nil(t, err)
+
+	_, err = itemModel.DeleteByID("invalid")
+	assert.Error(t, err)
+
+	res, err := itemModel.DeleteByID(item.ID.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.DeletedCount)
+
+	res, err = itemModel.DeleteByObjectID(item.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res.DeletedCount)
+
+	DropCollection("items")
+}
@@ -276,3 +276,47 @@ func TestAggregate(t *testing.T) {
 	DropCollection("items")
 
 }
+
+func TestFindByIDs(t *testing.T) {
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item3 := models.ItemSchema{ID: primitive.NewObjectID()}
+	itemModel := models.ItemModel()
+
+	_, err := itemModel.InsertMany([]interface{}{item1, item2, item3})
+	assert.Nil(t, err)
+
+	missing := primitive.NewObjectID()
+	results := []models.ItemSchema{}
+
+	report, err := itemModel.FindByIDs([]string{item3.ID.Hex(), "invalid", missing.Hex(), item1.ID.Hex(), item3.ID.Hex()}, &results)
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, item3.ID, results[0].ID)
+	assert.Equal(t, item1.ID, results[1].ID)
+	assert.Equal(t, []string{missing.Hex()}, report.Missing)
+	assert.Equal(t, []string{"invalid"}, report.Malformed)
+
+	DropCollection("items")
+}
+
+func TestFindByObjectIDs(t *testing.T) {
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+	itemModel := models.ItemModel()
+
+	_, err := itemModel.InsertMany([]interface{}{item1, item2})
+	assert.Nil(t, err)
+
+	results := []bson.M{}
+	report, err := itemModel.FindByObjectIDs([]primitive.ObjectID{item2.ID, item1.ID}, &results)
+
+	assert.Nil(t, err)
+	assert.Empty(t, report.Missing)
+	assert.Len(t, results, 2)
+	assert.Equal(t, item2.ID, results[0]["_id"])
+	assert.Equal(t, item1.ID, results[1]["_id"])
+
+	DropCollection("items")
+}
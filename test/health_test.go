@@ -0,0 +1,52 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+)
+
+func TestHealth(t *testing.T) {
+	status, err := yamgo.Health(context.TODO())
+
+	assert.Nil(t, err)
+	assert.True(t, status.Healthy)
+	assert.True(t, status.PrimaryAvailable)
+	assert.NotEmpty(t, status.ServerVersion)
+	assert.NotEmpty(t, status.Topology)
+	assert.Greater(t, status.Pool.Created, int64(0))
+}
+
+func TestReadinessHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/ready", nil)
+
+	yamgo.ReadinessHandler().ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	status := yamgo.HealthStatus{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.True(t, status.Healthy)
+}
+
+func TestLivenessHandlerDisconnected(t *testing.T) {
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "test",
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, client.Disconnect())
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/live", nil)
+
+	client.LivenessHandler().ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type hookedSchema struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Email    string             `bson:"email"`
+	Updates  int                `bson:"updates"`
+	Display  string             `bson:"-"`
+	Inserted bool               `bson:"-"`
+}
+
+var errRejected = errors.New("rejected")
+
+func (h *hookedSchema) BeforeInsert(ctx context.Context) error {
+	if h.Email == "" {
+		return errRejected
+	}
+	h.Email = strings.ToLower(h.Email)
+	return nil
+}
+
+func (h *hookedSchema) AfterInsert(ctx context.Context) error {
+	h.Inserted = true
+	return nil
+}
+
+func (h *hookedSchema) BeforeUpdate(ctx context.Context) error {
+	h.Updates++
+	return nil
+}
+
+func (h *hookedSchema) AfterFind(ctx context.Context) error {
+	h.Display = "<" + h.Email + ">"
+	return nil
+}
+
+func TestInsertHooks(t *testing.T) {
+	model := yamgo.NewModel("hooked")
+
+	doc := hookedSchema{ID: primitive.NewObjectID(), Email: "A@B.IT"}
+	_, err := model.InsertOne(&doc)
+	assert.Nil(t, err)
+	assert.Equal(t, "a@b.it", doc.Email)
+	assert.True(t, doc.Inserted)
+
+	_, err = model.InsertMany([]interface{}{hookedSchema{Email: "C@D.IT"}, hookedSchema{}})
+	assert.ErrorIs(t, err, errRejected)
+
+	count, err := model.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("hooked")
+}
+
+func TestUpdateAndFindHooks(t *testing.T) {
+	model := yamgo.NewModel("hooked")
+
+	doc := hookedSchema{Email: "a@b.it"}
+	_, err := model.Save(&doc)
+	assert.Nil(t, err)
+
+	_, err = model.Save(&doc)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, doc.Updates)
+
+	result := hookedSchema{}
+	err = model.FindByObjectID(doc.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Updates)
+	assert.Equal(t, "<a@b.it>", result.Display)
+
+	results := []hookedSchema{}
+	err = model.Find(bson.M{}, &results)
+	assert.Nil(t, err)
+	assert.Equal(t, "<a@b.it>", results[0].Display)
+
+	pointers := []*hookedSchema{}
+	_, err = model.PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 10}, &pointers)
+	assert.Nil(t, err)
+	assert.Equal(t, "<a@b.it>", pointers[0].Display)
+
+	DropCollection("hooked")
+}
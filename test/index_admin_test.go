@@ -0,0 +1,50 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIndexManagement(t *testing.T) {
+	model := yamgo.NewModel("admin")
+
+	name, err := model.CreateIndex(yamgo.Index{
+		Keys:   bson.D{{Key: "name", Value: yamgo.IndexAsc}, {Key: "createdAt", Value: yamgo.IndexDesc}},
+		Unique: true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "name_1_createdAt_-1", name)
+
+	err = model.HideIndex(name)
+	assert.Nil(t, err)
+
+	indexes, err := model.ListIndexes()
+	assert.Nil(t, err)
+	assert.Len(t, indexes, 2)
+	assert.Equal(t, name, indexes[1].Name)
+	assert.True(t, indexes[1].Unique)
+	assert.True(t, indexes[1].Hidden)
+	assert.Equal(t, "createdAt", indexes[1].Keys[1].Key)
+
+	err = model.UnhideIndex(name)
+	assert.Nil(t, err)
+
+	err = model.FindOne(bson.M{"name": "a"}, &bson.M{})
+	assert.NotNil(t, err)
+
+	usage, err := model.IndexUsage()
+	assert.Nil(t, err)
+	assert.Len(t, usage, 2)
+
+	err = model.DropIndex(name)
+	assert.Nil(t, err)
+
+	indexes, err = model.ListIndexes()
+	assert.Nil(t, err)
+	assert.Len(t, indexes, 1)
+
+	DropCollection("admin")
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type indexedLocationSchema struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+type indexedSchema struct {
+	ID        primitive.ObjectID    `bson:"_id,omitempty"`
+	Email     string                `bson:"email" index:",unique"`
+	Status    string                `bson:"status" index:"asc,name=status_created"`
+	CreatedAt time.Time             `bson:"createdAt" index:"desc,name=status_created"`
+	ExpiresAt *time.Time            `bson:"expiresAt,omitempty" index:",ttl=1h"`
+	Title     string                `bson:"title" index:"text"`
+	Location  indexedLocationSchema `bson:"location" index:"2dsphere"`
+}
+
+func indexNames(t *testing.T, model yamgo.Model) []string {
+	res, err := model.SyncIndexes(yamgo.SyncIndexesOptions{DryRun: true})
+	assert.Nil(t, err)
+	return append(res.Stale, res.Changed...)
+}
+
+func TestSyncIndexes(t *testing.T) {
+	model := yamgo.NewModel("indexed", yamgo.WithSchema(indexedSchema{}), yamgo.WithIndexes(yamgo.Index{
+		Keys:          bson.D{{Key: "status", Value: yamgo.IndexAsc}},
+		PartialFilter: bson.M{"status": "active"},
+	}))
+
+	res, err := model.SyncIndexes(yamgo.SyncIndexesOptions{DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email_1", "expiresAt_1", "location_2dsphere", "status_1", "status_created", "title_text"}, res.Created)
+
+	res, err = model.SyncIndexes(yamgo.SyncIndexesOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, res.Created, 6)
+
+	// A second run finds everything in place.
+	res, err = model.SyncIndexes(yamgo.SyncIndexesOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, res.Created)
+	assert.Empty(t, res.Stale)
+	assert.Empty(t, res.Changed)
+
+	DropCollection("indexed")
+}
+
+func TestSyncIndexesStale(t *testing.T) {
+	model := yamgo.NewModel("indexed", yamgo.WithSchema(indexedSchema{}))
+	_, err := model.SyncIndexes(yamgo.SyncIndexesOptions{})
+	assert.Nil(t, err)
+
+	changed := yamgo.NewModel("indexed", yamgo.WithIndexes(
+		yamgo.Index{Keys: bson.D{{Key: "email", Value: yamgo.IndexAsc}}},
+	))
+	assert.Equal(t, []string{"expiresAt_1", "location_2dsphere", "status_created", "title_text", "email_1"}, indexNames(t, changed))
+
+	res, err := changed.SyncIndexes(yamgo.SyncIndexesOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"email_1"}, res.Changed)
+	assert.Len(t, res.Stale, 4)
+	assert.Empty(t, res.Dropped)
+
+	res, err = changed.SyncIndexes(yamgo.SyncIndexesOptions{DropStale: true})
+	assert.Nil(t, err)
+	assert.Len(t, res.Dropped, 5)
+	assert.Equal(t, []string{"email_1"}, res.Created)
+
+	assert.Empty(t, indexNames(t, changed))
+
+	DropCollection("indexed")
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
 	"github.com/wezard-it/yamgo/test/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestInsertOne(t *testing.T) {
@@ -37,3 +39,50 @@ func TestInsertMany(t *testing.T) {
 	DropCollection("items")
 
 }
+
+func TestInsertManyUnorderedPartialFailure(t *testing.T) {
+
+	itemModel := models.ItemModel()
+
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+
+	result, err := itemModel.InsertMany([]interface{}{item1, item1, item2}, options.InsertMany().SetOrdered(false))
+
+	var insertErr *yamgo.BulkInsertError
+	assert.ErrorAs(t, err, &insertErr)
+
+	assert.Equal(t, []interface{}{item1.ID, item2.ID}, result.InsertedIDs)
+	assert.Equal(t, []int{1}, insertErr.FailedIndexes())
+	assert.Equal(t, item1.ID, insertErr.Failures[0].ID)
+	assert.True(t, insertErr.Failures[0].IsDuplicateKey())
+	assert.Empty(t, insertErr.Unprocessed)
+
+	DropCollection("items")
+
+}
+
+func TestInsertManyOrderedPartialFailure(t *testing.T) {
+
+	itemModel := models.ItemModel()
+
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+
+	result, err := itemModel.InsertMany([]interface{}{item1, item1, item2})
+
+	var insertErr *yamgo.BulkInsertError
+	assert.ErrorAs(t, err, &insertErr)
+
+	assert.Equal(t, []interface{}{item1.ID}, result.InsertedIDs)
+	assert.Equal(t, []int{1}, insertErr.FailedIndexes())
+	assert.Equal(t, []int{2}, insertErr.Unprocessed)
+
+	DropCollection("items")
+
+}
+
+func TestBulkErrorsWithoutFailures(t *testing.T) {
+	assert.Equal(t, "insert many failed", (&yamgo.BulkInsertError{}).Error())
+	assert.Equal(t, "bulk write failed", (&yamgo.BulkWriteError{}).Error())
+}
@@ -0,0 +1,47 @@
+//go:build go1.23
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFindSeq(t *testing.T) {
+	insertItems(t, 10)
+	itemModel := models.ItemModel()
+
+	count := 0
+	for doc, err := range itemModel.FindSeq(bson.M{}) {
+		assert.Nil(t, err)
+		assert.NotEmpty(t, doc)
+		count++
+		if count == 4 {
+			break
+		}
+	}
+
+	assert.Equal(t, 4, count)
+
+	DropCollection("items")
+}
+
+func TestTypedFindSeq(t *testing.T) {
+	insertItems(t, 10)
+	itemModel := yamgo.Typed[models.ItemSchema](models.ItemModel())
+
+	count := 0
+	for item, err := range itemModel.FindSeq(bson.M{}) {
+		assert.Nil(t, err)
+		assert.False(t, item.ID.IsZero())
+		count++
+	}
+
+	assert.Equal(t, 10, count)
+
+	DropCollection("items")
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Log(_ context.Context, _ yamgo.LogLevel, msg string, _ ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, msg)
+}
+
+func (l *recordingLogger) Messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string{}, l.messages...)
+}
+
+func TestLoggerConnectionLifecycle(t *testing.T) {
+	logger := &recordingLogger{}
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "test",
+		Logger:        logger,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, client.Disconnect())
+
+	assert.Contains(t, logger.Messages(), "connected to db")
+	assert.Contains(t, logger.Messages(), "disconnecting from db")
+}
+
+func TestLoggerInvalidCursor(t *testing.T) {
+	logger := &recordingLogger{}
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "test",
+		Logger:        logger,
+		LogLevel:      yamgo.LogLevelWarn,
+	})
+	assert.Nil(t, err)
+
+	itemModel := client.NewModel("items")
+	results := []bson.M{}
+	_, err = itemModel.PaginatedFind(yamgo.PaginationFindParams{
+		Query: bson.M{},
+		Limit: 1,
+		Next:  "not a cursor",
+	}, &results)
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"invalid pagination cursor"}, logger.Messages())
+
+	assert.Nil(t, client.Disconnect())
+}
+
+func TestLoggerSilent(t *testing.T) {
+	logger := &recordingLogger{}
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "test",
+		Logger:        logger,
+		LogLevel:      yamgo.LogLevelSilent,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, client.Disconnect())
+
+	assert.Empty(t, logger.Messages())
+}
+
+func TestLoggerExpectedFailures(t *testing.T) {
+	logger := &recordingLogger{}
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "logger",
+		Logger:        logger,
+		LogLevel:      yamgo.LogLevelInfo,
+	})
+	assert.Nil(t, err)
+
+	// The missing collection is created after a NamespaceNotFound failure.
+	model := client.NewModel("schemas", yamgo.WithSchema(struct {
+		Name string `bson:"name"`
+	}{}))
+	assert.Nil(t, model.ApplySchema())
+	assert.NotContains(t, logger.Messages(), "command failed")
+
+	assert.Nil(t, client.Database.Drop(context.TODO()))
+	assert.Nil(t, client.Disconnect())
+}
+
+func TestSetLoggerWhileRunning(t *testing.T) {
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "test",
+	})
+	assert.Nil(t, err)
+
+	model := client.NewModel("items")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_, _ = model.CountDocuments(bson.M{})
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		client.SetLogger(&recordingLogger{}, yamgo.LogLevelDebug)
+	}
+	wg.Wait()
+
+	assert.Nil(t, client.Disconnect())
+}
@@ -0,0 +1,226 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type tenantKey struct{}
+
+func TestModelMiddleware(t *testing.T) {
+	model := yamgo.NewModel("middleware")
+
+	kinds := []yamgo.OperationKind{}
+	model.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		kinds = append(kinds, op.Kind)
+		assert.Equal(t, "middleware", op.Collection)
+		return next(ctx, op)
+	})
+
+	_, err := model.InsertMany([]interface{}{bson.M{"name": "a"}, bson.M{"name": "b"}})
+	assert.Nil(t, err)
+
+	count, err := model.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	results := []bson.M{}
+	_, err = model.PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 10}, &results)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+
+	assert.Equal(t, []yamgo.OperationKind{yamgo.OperationInsertMany, yamgo.OperationCount, yamgo.OperationPaginatedFind}, kinds)
+
+	DropCollection("middleware")
+}
+
+func TestMiddlewareWriteKinds(t *testing.T) {
+	model := yamgo.NewModel("middleware", yamgo.WithSoftDelete())
+
+	kinds := []yamgo.OperationKind{}
+	model.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		kinds = append(kinds, op.Kind)
+		return next(ctx, op)
+	})
+
+	_, err := model.ReplaceOne(bson.M{"name": "a"}, bson.M{"name": "a"}, options.Replace().SetUpsert(true))
+	assert.Nil(t, err)
+
+	result := bson.M{}
+	err = model.FindOneAndUpdate(bson.M{"name": "a"}, bson.M{"$set": bson.M{"n": 1}}, &result)
+	assert.Nil(t, err)
+
+	err = model.FindOneAndReplace(bson.M{"name": "a"}, bson.M{"name": "a"}, &result)
+	assert.Nil(t, err)
+
+	_, err = model.Bulk().Insert(bson.M{"name": "b"}).Execute()
+	assert.Nil(t, err)
+
+	err = model.FindOneAndDelete(bson.M{"name": "b"}, &result)
+	assert.Nil(t, err)
+
+	_, err = model.DeleteOne(bson.M{"name": "a"})
+	assert.Nil(t, err)
+
+	_, err = model.HardDelete(bson.M{})
+	assert.Nil(t, err)
+
+	// Soft deletes run with their delete kind.
+	assert.Equal(t, []yamgo.OperationKind{
+		yamgo.OperationReplaceOne,
+		yamgo.OperationFindOneAndUpdate,
+		yamgo.OperationFindOneAndReplace,
+		yamgo.OperationBulkWrite,
+		yamgo.OperationFindOneAndDelete,
+		yamgo.OperationDeleteOne,
+		yamgo.OperationDeleteMany,
+	}, kinds)
+
+	DropCollection("middleware")
+}
+
+func TestMiddlewarePaginatedPage(t *testing.T) {
+	cached := &yamgo.Page{Count: 42}
+
+	model := yamgo.NewModel("middleware")
+	model.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		if op.Kind == yamgo.OperationPaginatedFind {
+			op.Page = cached
+			return nil
+		}
+		op.Result = "not a slice"
+		return next(ctx, op)
+	})
+
+	results := []bson.M{}
+	page, err := model.PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 10}, &results)
+	assert.Nil(t, err)
+	assert.Equal(t, 42, page.Count)
+
+	raw := []bson.Raw{}
+	_, err = model.PaginatedAggregate(&raw, "", "", 10)
+	assert.NotNil(t, err)
+
+	skipping := yamgo.NewModel("middleware")
+	skipping.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		return nil
+	})
+
+	_, err = skipping.PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 10}, &results)
+	assert.NotNil(t, err)
+}
+
+func TestMiddlewareStreamingReads(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+
+	model := yamgo.NewModel("middleware")
+	_, err := model.InsertOne(bson.M{"name": "a"})
+	assert.Nil(t, err)
+
+	kinds := []yamgo.OperationKind{}
+	model.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		kinds = append(kinds, op.Kind)
+		return errForbidden
+	})
+
+	err = model.FindEach(bson.M{}, func(doc bson.Raw) error { return nil })
+	assert.ErrorIs(t, err, errForbidden)
+
+	err = model.AggregateEach(mongo.Pipeline{}, func(doc bson.Raw) error { return nil })
+	assert.ErrorIs(t, err, errForbidden)
+
+	err = model.FindAndPopulateEach(bson.M{}, options.FindOptions{}, nil, func(doc bson.Raw) error { return nil })
+	assert.ErrorIs(t, err, errForbidden)
+
+	stream := model.FindStream(bson.M{}, 1)
+	for range stream.Documents() {
+		t.Fatal("the stream should be empty")
+	}
+	assert.ErrorIs(t, stream.Close(), errForbidden)
+
+	assert.Equal(t, []yamgo.OperationKind{
+		yamgo.OperationFindEach,
+		yamgo.OperationAggregateEach,
+		yamgo.OperationFindEach,
+		yamgo.OperationFindStream,
+	}, kinds)
+
+	DropCollection("middleware")
+}
+
+func TestMiddlewareRewritesAndShortCircuits(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+
+	model := yamgo.NewModel("middleware")
+	model.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		if !ok {
+			return errForbidden
+		}
+		if op.Filter != nil {
+			op.Filter = bson.M{"$and": bson.A{op.Filter, bson.M{"tenant": tenant}}}
+		}
+		if doc, ok := op.Document.(bson.M); ok {
+			doc["tenant"] = tenant
+		}
+		return next(ctx, op)
+	})
+
+	_, err := model.InsertOne(bson.M{"name": "a"})
+	assert.ErrorIs(t, err, errForbidden)
+
+	acme := model.WithContext(context.WithValue(context.Background(), tenantKey{}, "acme"))
+	other := model.WithContext(context.WithValue(context.Background(), tenantKey{}, "other"))
+
+	_, err = acme.InsertOne(bson.M{"name": "a"})
+	assert.Nil(t, err)
+
+	exists, err := acme.Exists(bson.M{"name": "a"})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = other.Exists(bson.M{"name": "a"})
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	DropCollection("middleware")
+}
+
+func TestClientMiddleware(t *testing.T) {
+	client, err := yamgo.NewClient(yamgo.ConnectionParams{
+		ConnectionUrl: connectionURI,
+		DbName:        "middleware",
+	})
+	assert.Nil(t, err)
+
+	order := []string{}
+	model := client.NewModel("items")
+	model.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		order = append(order, "model")
+		return next(ctx, op)
+	})
+
+	// Client middleware also applies to models created before.
+	client.Use(func(ctx context.Context, op *yamgo.Operation, next yamgo.Handler) error {
+		order = append(order, "client")
+		err := next(ctx, op)
+		if op.Kind == yamgo.OperationCount {
+			assert.Equal(t, 0, op.Result)
+		}
+		return err
+	})
+
+	_, err = model.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"client", "model"}, order)
+
+	assert.Nil(t, client.Database.Drop(context.TODO()))
+	assert.Nil(t, client.Disconnect())
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestFindOneAndUpdate(t *testing.T) {
+	fooModel := models.FooModel()
+	id := primitive.NewObjectID()
+
+	_, err := fooModel.InsertOne(bson.M{"_id": id, "item": 10})
+	assert.Nil(t, err)
+
+	before := bson.M{}
+	err = fooModel.FindOneAndUpdate(bson.M{"_id": id}, bson.M{"$inc": bson.M{"item": -1}}, &before)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(10), before["item"])
+
+	after := bson.M{}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"item": 1})
+	err = fooModel.FindOneAndUpdate(bson.M{"_id": id}, bson.M{"$inc": bson.M{"item": -1}}, &after, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8), after["item"])
+
+	DropCollection("foos")
+}
+
+func TestFindOneAndUpdateNoDocuments(t *testing.T) {
+	fooModel := models.FooModel()
+
+	result := bson.M{}
+	err := fooModel.FindOneAndUpdate(bson.M{"_id": primitive.NewObjectID()}, bson.M{"$set": bson.M{"item": 1}}, &result)
+
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	assert.Empty(t, result)
+}
+
+func TestFindOneAndUpdateUpsert(t *testing.T) {
+	fooModel := models.FooModel()
+	id := primitive.NewObjectID()
+
+	result := models.FooSchema{}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := fooModel.FindOneAndUpdate(bson.M{"_id": id}, bson.M{"$set": bson.M{"item": "created"}}, &result, opts)
+
+	assert.Nil(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.Equal(t, "created", result.Item)
+
+	DropCollection("foos")
+}
+
+func TestFindOneAndReplace(t *testing.T) {
+	foo := models.FooSchema{ID: primitive.NewObjectID(), Item: "before"}
+	fooModel := models.FooModel()
+
+	_, err := fooModel.InsertOne(&foo)
+	assert.Nil(t, err)
+
+	result := models.FooSchema{}
+	replacement := models.FooSchema{ID: foo.ID, Item: "after"}
+	err = fooModel.FindOneAndReplace(bson.M{"_id": foo.ID}, &replacement, &result)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "before", result.Item)
+
+	DropCollection("foos")
+}
+
+func TestFindOneAndDelete(t *testing.T) {
+	fooModel := models.FooModel()
+
+	foos := []interface{}{
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "job"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "job"},
+	}
+	_, err := fooModel.InsertMany(foos)
+	assert.Nil(t, err)
+
+	result := models.FooSchema{}
+	err = fooModel.FindOneAndDelete(bson.M{"item": "job"}, &result, options.FindOneAndDelete().SetSort(bson.M{"_id": -1}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, foos[1].(models.FooSchema).ID, result.ID)
+
+	count, err := fooModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("foos")
+}
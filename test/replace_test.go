@@ -0,0 +1,118 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReplaceOne(t *testing.T) {
+	foo := models.FooSchema{ID: primitive.NewObjectID(), Item: "before"}
+	fooModel := models.FooModel()
+
+	_, err := fooModel.InsertOne(&foo)
+	assert.Nil(t, err)
+
+	foo.Item = "after"
+	res, err := fooModel.ReplaceOne(bson.M{"_id": foo.ID}, &foo)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	result := models.FooSchema{}
+	err = fooModel.FindByObjectID(foo.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, "after", result.Item)
+
+	DropCollection("foos")
+}
+
+func TestSaveInsertsNewDocument(t *testing.T) {
+	foo := models.FooSchema{Item: "new"}
+	fooModel := models.FooModel()
+
+	id, err := fooModel.Save(&foo)
+
+	assert.Nil(t, err)
+	assert.False(t, foo.ID.IsZero())
+	assert.Equal(t, foo.ID, id)
+
+	count, err := fooModel.CountDocuments(bson.M{"_id": foo.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("foos")
+}
+
+func TestSaveReplacesExistingDocument(t *testing.T) {
+	foo := models.FooSchema{Item: "before"}
+	fooModel := models.FooModel()
+
+	id, err := fooModel.Save(&foo)
+	assert.Nil(t, err)
+
+	foo.Item = "after"
+	savedID, err := fooModel.Save(&foo)
+	assert.Nil(t, err)
+	assert.Equal(t, id, savedID)
+
+	results := []models.FooSchema{}
+	err = fooModel.Find(bson.M{}, &results)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "after", results[0].Item)
+
+	DropCollection("foos")
+}
+
+func TestSaveUpsertsWithGivenID(t *testing.T) {
+	foo := models.FooSchema{ID: primitive.NewObjectID(), Item: "upserted"}
+	fooModel := models.FooModel()
+
+	id, err := fooModel.Save(&foo)
+	assert.Nil(t, err)
+	assert.Equal(t, foo.ID, id)
+
+	count, err := fooModel.CountDocuments(bson.M{"_id": foo.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("foos")
+}
+
+func TestSaveRequiresPointer(t *testing.T) {
+	fooModel := models.FooModel()
+
+	_, err := fooModel.Save(models.FooSchema{})
+
+	assert.Error(t, err)
+}
+
+func TestSaveStringKeyedMaps(t *testing.T) {
+	fooModel := models.FooModel()
+
+	doc := map[string]interface{}{"item": "a"}
+	id, err := fooModel.Save(doc)
+	assert.Nil(t, err)
+	assert.Equal(t, id, doc["_id"])
+
+	doc["item"] = "b"
+	savedID, err := fooModel.Save(&doc)
+	assert.Nil(t, err)
+	assert.Equal(t, id, savedID)
+
+	count, err := fooModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = fooModel.Save(map[string]string{"item": "c"})
+	assert.Error(t, err)
+
+	_, err = fooModel.Save(map[int]interface{}{1: "c"})
+	assert.Error(t, err)
+
+	DropCollection("foos")
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type schemaTagSchema struct {
+	Label string `bson:"label"`
+}
+
+type schemaSchema struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Name      string             `bson:"name" validate:"min=2"`
+	Status    string             `bson:"status" validate:"enum=draft|published"`
+	Price     float64            `bson:"price"`
+	Note      *string            `bson:"note"`
+	Tags      []schemaTagSchema  `bson:"tags"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema, err := yamgo.JSONSchema(models.FooSchema{})
+	assert.Nil(t, err)
+	assert.Equal(t, bson.M{
+		"bsonType": "object",
+		"properties": bson.M{
+			"_id":  bson.M{"bsonType": "objectId"},
+			"item": bson.M{},
+		},
+	}, schema)
+
+	schema, err = yamgo.JSONSchema(&schemaSchema{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"name", "status", "price", "tags", "createdAt"}, schema["required"])
+
+	properties := schema["properties"].(bson.M)
+	assert.Equal(t, bson.M{"bsonType": "string", "minLength": int64(2)}, properties["name"])
+	assert.Equal(t, bson.M{"bsonType": "string", "enum": bson.A{"draft", "published"}}, properties["status"])
+	assert.Equal(t, bson.M{"bsonType": bson.A{"string", "null"}}, properties["note"])
+	assert.Equal(t, bson.M{"bsonType": "date"}, properties["createdAt"])
+	assert.Equal(t, bson.M{
+		"bsonType": bson.A{"array", "null"},
+		"items": bson.M{
+			"bsonType":   "object",
+			"properties": bson.M{"label": bson.M{"bsonType": "string"}},
+			"required":   []string{"label"},
+		},
+	}, properties["tags"])
+}
+
+func TestApplySchema(t *testing.T) {
+	model := yamgo.NewModel("schemas", yamgo.WithSchema(schemaSchema{}))
+
+	err := model.ApplySchema()
+	assert.Nil(t, err)
+
+	// Applying it again modifies the existing collection.
+	err = model.ApplySchema()
+	assert.Nil(t, err)
+
+	_, err = model.InsertOne(schemaSchema{Name: "ok", Status: "draft", CreatedAt: time.Now()})
+	assert.Nil(t, err)
+
+	_, err = model.InsertOne(bson.M{"name": "missing fields"})
+	var writeErr mongo.WriteException
+	assert.ErrorAs(t, err, &writeErr)
+	assert.Equal(t, 121, writeErr.WriteErrors[0].Code)
+
+	DropCollection("schemas")
+}
+
+func TestApplySchemaWithWarnAction(t *testing.T) {
+	model := yamgo.NewModel("schemas",
+		yamgo.WithSchema(schemaSchema{}),
+		yamgo.WithValidationAction(yamgo.ValidationActionWarn))
+
+	err := model.ApplySchema()
+	assert.Nil(t, err)
+
+	_, err = model.InsertOne(bson.M{"name": "missing fields"})
+	assert.Nil(t, err)
+
+	DropCollection("schemas")
+}
@@ -0,0 +1,181 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestSoftDelete(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithSoftDelete())
+
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+
+	_, err := itemModel.InsertMany([]interface{}{item1, item2})
+	assert.Nil(t, err)
+
+	res, err := itemModel.DeleteByObjectID(item1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.DeletedCount)
+
+	result := models.ItemSchema{}
+	err = itemModel.FindByObjectID(item1.ID, &result)
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	count, err := itemModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = itemModel.WithDeleted().CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	results := []models.ItemSchema{}
+	err = itemModel.OnlyDeleted().Find(bson.M{}, &results)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, item1.ID, results[0].ID)
+
+	DropCollection("items")
+}
+
+func TestSoftDeleteRestoreAndHardDelete(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithSoftDelete())
+
+	item := models.ItemSchema{ID: primitive.NewObjectID()}
+	_, err := itemModel.InsertOne(&item)
+	assert.Nil(t, err)
+
+	_, err = itemModel.DeleteMany(bson.M{})
+	assert.Nil(t, err)
+
+	exists, err := itemModel.Exists(bson.M{"_id": item.ID})
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	restored, err := itemModel.Restore(bson.M{"_id": item.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), restored.ModifiedCount)
+
+	exists, err = itemModel.Exists(bson.M{"_id": item.ID})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	deleted, err := itemModel.HardDelete(bson.M{"_id": item.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), deleted.DeletedCount)
+
+	count, err := itemModel.WithDeleted().CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	DropCollection("items")
+}
+
+func TestSoftDeletePaginatedFind(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithSoftDelete())
+
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+
+	_, err := itemModel.InsertMany([]interface{}{item1, item2})
+	assert.Nil(t, err)
+
+	_, err = itemModel.DeleteByObjectID(item1.ID)
+	assert.Nil(t, err)
+
+	results := []models.ItemSchema{}
+	page, err := itemModel.PaginatedFind(yamgo.PaginationFindParams{
+		Query:      bson.M{},
+		Limit:      10,
+		CountTotal: true,
+	}, &results)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, page.Count)
+	assert.Len(t, results, 1)
+	assert.Equal(t, item2.ID, results[0].ID)
+
+	DropCollection("items")
+}
+
+func TestSoftDeletePopulate(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithSoftDelete())
+	fooModel := yamgo.NewModel("foos", yamgo.WithSoftDeletedLookup("items", yamgo.DefaultDeletedAtField))
+
+	item := models.ItemSchema{ID: primitive.NewObjectID()}
+	foo := models.FooSchema{ID: primitive.NewObjectID(), Item: item.ID}
+
+	_, err := itemModel.InsertOne(&item)
+	assert.Nil(t, err)
+	_, err = fooModel.InsertOne(&foo)
+	assert.Nil(t, err)
+
+	_, err = itemModel.DeleteByObjectID(item.ID)
+	assert.Nil(t, err)
+
+	populateOptions := []yamgo.PopulateOptions{{Collection: "items", LocalField: "item", As: "item"}}
+
+	results := []bson.M{}
+	err = fooModel.FindAndPopulate(bson.M{"_id": foo.ID}, options.FindOptions{}, populateOptions, &results)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Nil(t, results[0]["item"])
+
+	results = []bson.M{}
+	err = fooModel.WithDeleted().FindAndPopulate(bson.M{"_id": foo.ID}, options.FindOptions{}, populateOptions, &results)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, item.ID, results[0]["item"].(bson.M)["_id"])
+
+	populateOptions[0].WithDeleted = true
+	results = []bson.M{}
+	err = fooModel.FindAndPopulate(bson.M{"_id": foo.ID}, options.FindOptions{}, populateOptions, &results)
+	assert.Nil(t, err)
+	assert.Equal(t, item.ID, results[0]["item"].(bson.M)["_id"])
+
+	// Models without the lookup configuration populate every document.
+	plainModel := models.FooModel()
+	populateOptions[0].WithDeleted = false
+	results = []bson.M{}
+	err = plainModel.FindAndPopulate(bson.M{"_id": foo.ID}, options.FindOptions{}, populateOptions, &results)
+	assert.Nil(t, err)
+	assert.Equal(t, item.ID, results[0]["item"].(bson.M)["_id"])
+
+	DropCollection("items")
+	DropCollection("foos")
+}
+
+func TestSoftDeletePaginatedAggregate(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithSoftDelete())
+
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+	_, err := itemModel.InsertMany([]interface{}{item1, item2})
+	assert.Nil(t, err)
+
+	_, err = itemModel.DeleteByObjectID(item1.ID)
+	assert.Nil(t, err)
+
+	results := []bson.Raw{}
+	page, err := itemModel.PaginatedAggregate(&results, "", "", 10, bson.M{"$sort": bson.M{"_id": 1}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, page.Count)
+	assert.Len(t, results, 1)
+
+	DropCollection("items")
+}
+
+func TestRestoreRequiresSoftDelete(t *testing.T) {
+	itemModel := models.ItemModel()
+
+	_, err := itemModel.Restore(bson.M{})
+	assert.ErrorIs(t, err, yamgo.ErrNotSoftDeleting)
+}
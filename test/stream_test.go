@@ -0,0 +1,128 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func insertItems(t *testing.T, n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = models.ItemSchema{ID: primitive.NewObjectID()}
+	}
+
+	itemModel := models.ItemModel()
+	_, err := itemModel.InsertMany(items)
+	assert.Nil(t, err)
+
+	return items
+}
+
+func TestFindEach(t *testing.T) {
+	insertItems(t, 25)
+	itemModel := models.ItemModel()
+
+	count := 0
+	err := itemModel.FindEach(bson.M{}, func(doc bson.Raw) error {
+		count++
+		return nil
+	}, options.Find().SetBatchSize(10))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 25, count)
+
+	DropCollection("items")
+}
+
+func TestFindEachStopsOnError(t *testing.T) {
+	insertItems(t, 5)
+	itemModel := models.ItemModel()
+
+	stop := errors.New("stop")
+	count := 0
+	err := itemModel.FindEach(bson.M{}, func(doc bson.Raw) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, count)
+
+	DropCollection("items")
+}
+
+func TestAggregateEach(t *testing.T) {
+	items := insertItems(t, 3)
+	itemModel := models.ItemModel()
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"_id": items[0].(models.ItemSchema).ID}}}}
+	ids := []interface{}{}
+	err := itemModel.AggregateEach(pipeline, func(doc bson.Raw) error {
+		ids = append(ids, doc.Lookup("_id").ObjectID())
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{items[0].(models.ItemSchema).ID}, ids)
+
+	DropCollection("items")
+}
+
+func TestTypedFindEach(t *testing.T) {
+	insertItems(t, 3)
+	itemModel := yamgo.Typed[models.ItemSchema](models.ItemModel())
+
+	ids := []primitive.ObjectID{}
+	err := itemModel.FindEach(bson.M{}, func(item models.ItemSchema) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, ids, 3)
+
+	DropCollection("items")
+}
+
+func TestFindStream(t *testing.T) {
+	insertItems(t, 20)
+	itemModel := models.ItemModel()
+
+	stream := itemModel.FindStream(bson.M{}, 2, options.Find().SetBatchSize(5))
+
+	count := 0
+	for range stream.Documents() {
+		count++
+	}
+
+	assert.Nil(t, stream.Err())
+	assert.Equal(t, 20, count)
+	assert.Nil(t, stream.Close())
+
+	DropCollection("items")
+}
+
+func TestFindStreamCloseEarly(t *testing.T) {
+	insertItems(t, 20)
+	itemModel := models.ItemModel()
+
+	stream := itemModel.FindStream(bson.M{}, 1)
+
+	doc := <-stream.Documents()
+	assert.NotEmpty(t, doc)
+
+	assert.Nil(t, stream.Close())
+
+	DropCollection("items")
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOperationTimeout(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithOperationTimeout(yamgo.OperationFind, time.Nanosecond))
+
+	_, err := itemModel.InsertOne(bson.M{"_id": primitive.NewObjectID()})
+	assert.Nil(t, err)
+
+	results := []bson.M{}
+	err = itemModel.Find(bson.M{}, &results)
+	assert.Error(t, err)
+
+	err = itemModel.WithTimeout(time.Minute).Find(bson.M{}, &results)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+
+	DropCollection("items")
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	itemModel := yamgo.NewModel("items",
+		yamgo.WithDefaultTimeout(time.Nanosecond),
+		yamgo.WithOperationTimeout(yamgo.OperationInsertOne, time.Minute),
+	)
+
+	_, err := itemModel.InsertOne(bson.M{"_id": primitive.NewObjectID()})
+	assert.Nil(t, err)
+
+	_, err = itemModel.CountDocuments(bson.M{})
+	assert.Error(t, err)
+
+	DropCollection("items")
+}
+
+func TestWithTimeoutUnderContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	itemModel := yamgo.NewModel("items")
+
+	results := []bson.M{}
+	err := itemModel.WithContext(ctx).WithTimeout(time.Nanosecond).Find(bson.M{}, &results)
+	assert.Error(t, err)
+
+	err = itemModel.WithContext(ctx).WithTimeout(time.Hour).Find(bson.M{}, &results)
+	assert.Nil(t, err)
+}
+
+func TestPaginatedTimeouts(t *testing.T) {
+	itemModel := yamgo.NewModel("items",
+		yamgo.WithOperationTimeout(yamgo.OperationPaginatedFind, time.Nanosecond),
+		yamgo.WithOperationTimeout(yamgo.OperationPaginatedAggregate, time.Nanosecond),
+	)
+
+	_, err := itemModel.InsertOne(bson.M{"_id": primitive.NewObjectID()})
+	assert.Nil(t, err)
+
+	results := []bson.M{}
+	_, err = itemModel.PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 10}, &results)
+	assert.Error(t, err)
+
+	raw := []bson.Raw{}
+	_, err = itemModel.PaginatedAggregate(&raw, "", "", 10, bson.M{"$match": bson.M{}})
+	assert.Error(t, err)
+
+	_, err = itemModel.WithTimeout(time.Minute).PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 10}, &results)
+	assert.Nil(t, err)
+
+	DropCollection("items")
+}
+
+func TestExistsUsesFindOneTimeout(t *testing.T) {
+	itemModel := yamgo.NewModel("items", yamgo.WithOperationTimeout(yamgo.OperationFindOne, time.Nanosecond))
+
+	_, err := itemModel.Exists(bson.M{})
+	assert.Error(t, err)
+
+	itemModel = yamgo.NewModel("items",
+		yamgo.WithOperationTimeout(yamgo.OperationFindOne, time.Nanosecond),
+		yamgo.WithOperationTimeout(yamgo.OperationExists, time.Minute),
+	)
+
+	_, err = itemModel.Exists(bson.M{})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,172 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type stampedSchema struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Name      string             `bson:"name"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt"`
+}
+
+type taggedSchema struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	Created time.Time          `bson:"created" yamgo:"createdAt"`
+	Updated *time.Time         `bson:"updated,omitempty" yamgo:"updatedAt"`
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestTimestampsOnInsertAndUpdate(t *testing.T) {
+	clock := newFakeClock()
+	model := yamgo.NewModel("stamped", yamgo.WithTimestamps(), yamgo.WithClock(clock.Now))
+
+	doc := stampedSchema{ID: primitive.NewObjectID(), Name: "a"}
+	_, err := model.InsertOne(&doc)
+	assert.Nil(t, err)
+	assert.Equal(t, clock.now, doc.CreatedAt)
+	assert.Equal(t, clock.now, doc.UpdatedAt)
+
+	created := clock.now
+	clock.Advance(time.Hour)
+
+	_, err = model.UpdateByObjectID(doc.ID, bson.M{"$set": bson.M{"name": "b"}})
+	assert.Nil(t, err)
+
+	result := stampedSchema{}
+	err = model.FindByObjectID(doc.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, created, result.CreatedAt)
+	assert.Equal(t, clock.now, result.UpdatedAt)
+
+	DropCollection("stamped")
+}
+
+func TestTimestampsOnUpsert(t *testing.T) {
+	clock := newFakeClock()
+	model := yamgo.NewModel("stamped", yamgo.WithTimestamps(), yamgo.WithClock(clock.Now))
+
+	id := primitive.NewObjectID()
+	_, err := model.UpdateOne(bson.M{"_id": id}, bson.M{"$set": bson.M{"name": "a"}}, options.Update().SetUpsert(true))
+	assert.Nil(t, err)
+
+	result := stampedSchema{}
+	err = model.FindByObjectID(id, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, clock.now, result.CreatedAt)
+	assert.Equal(t, clock.now, result.UpdatedAt)
+
+	DropCollection("stamped")
+}
+
+func TestTimestampsOnReplaceKeepCreatedAt(t *testing.T) {
+	clock := newFakeClock()
+	model := yamgo.NewModel("stamped", yamgo.WithTimestamps(), yamgo.WithClock(clock.Now))
+
+	doc := stampedSchema{Name: "a"}
+	_, err := model.Save(&doc)
+	assert.Nil(t, err)
+
+	created := clock.now
+	clock.Advance(time.Minute)
+
+	doc.Name = "b"
+	_, err = model.Save(&doc)
+	assert.Nil(t, err)
+
+	result := stampedSchema{}
+	err = model.FindByObjectID(doc.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, created, result.CreatedAt)
+	assert.Equal(t, clock.now, result.UpdatedAt)
+
+	DropCollection("stamped")
+}
+
+func TestTimestampsOnReplaceWithoutCreatedAt(t *testing.T) {
+	clock := newFakeClock()
+	model := yamgo.NewModel("stamped", yamgo.WithTimestamps(), yamgo.WithClock(clock.Now))
+
+	id := primitive.NewObjectID()
+	_, err := model.InsertOne(bson.M{"_id": id, "name": "a"})
+	assert.Nil(t, err)
+
+	clock.Advance(time.Minute)
+
+	_, err = model.ReplaceOne(bson.M{"_id": id}, bson.M{"name": "b"})
+	assert.Nil(t, err)
+
+	result := bson.M{}
+	err = model.FindByObjectID(id, &result)
+	assert.Nil(t, err)
+	assert.NotContains(t, result, "createdAt")
+
+	upsertID := primitive.NewObjectID()
+	_, err = model.ReplaceOne(bson.M{"_id": upsertID}, bson.M{"name": "c"}, options.Replace().SetUpsert(true))
+	assert.Nil(t, err)
+
+	stamped := stampedSchema{}
+	err = model.FindByObjectID(upsertID, &stamped)
+	assert.Nil(t, err)
+	assert.Equal(t, clock.now, stamped.CreatedAt)
+
+	DropCollection("stamped")
+}
+
+func TestTimestampsInBulk(t *testing.T) {
+	clock := newFakeClock()
+	model := yamgo.NewModel("stamped", yamgo.WithTimestamps(), yamgo.WithClock(clock.Now))
+
+	id := primitive.NewObjectID()
+	_, err := model.Bulk().
+		Insert(bson.M{"_id": id, "name": "a"}).
+		Execute()
+	assert.Nil(t, err)
+
+	result := stampedSchema{}
+	err = model.FindByObjectID(id, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, clock.now, result.CreatedAt)
+
+	DropCollection("stamped")
+}
+
+func TestTimestampsFromStructTags(t *testing.T) {
+	clock := newFakeClock()
+	model := yamgo.NewModel("stamped", yamgo.WithClock(clock.Now))
+
+	doc := taggedSchema{ID: primitive.NewObjectID()}
+	_, err := model.InsertOne(doc)
+	assert.Nil(t, err)
+
+	result := taggedSchema{}
+	err = model.FindByObjectID(doc.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, clock.now, result.Created)
+	assert.Equal(t, clock.now, *result.Updated)
+
+	DropCollection("stamped")
+}
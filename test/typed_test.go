@@ -0,0 +1,123 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestTypedModelFind(t *testing.T) {
+	fooModel := yamgo.NewTypedModel[models.FooSchema]("foos")
+
+	foo1 := models.FooSchema{ID: primitive.NewObjectID(), Item: "a"}
+	foo2 := models.FooSchema{ID: primitive.NewObjectID(), Item: "b"}
+
+	_, err := fooModel.InsertMany([]models.FooSchema{foo1, foo2})
+	assert.Nil(t, err)
+
+	results, err := fooModel.Find(bson.M{})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+
+	result, err := fooModel.FindOne(bson.M{"item": "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, foo2.ID, result.ID)
+
+	result, err = fooModel.FindByID(foo1.ID.Hex())
+	assert.Nil(t, err)
+	assert.Equal(t, "a", result.Item)
+
+	DropCollection("foos")
+}
+
+func TestTypedModelPaginatedFind(t *testing.T) {
+	itemModel := yamgo.Typed[models.ItemSchema](models.ItemModel())
+
+	item1 := models.ItemSchema{ID: primitive.NewObjectID()}
+	item2 := models.ItemSchema{ID: primitive.NewObjectID()}
+
+	_, err := itemModel.InsertMany([]models.ItemSchema{item1, item2})
+	assert.Nil(t, err)
+
+	results, page, err := itemModel.PaginatedFind(yamgo.PaginationFindParams{
+		Query:         bson.M{},
+		Limit:         1,
+		SortAscending: true,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, item1.ID, results[0].ID)
+	assert.True(t, page.HasNext)
+
+	results, _, err = itemModel.PaginatedFind(yamgo.PaginationFindParams{
+		Query:         bson.M{},
+		Limit:         1,
+		SortAscending: true,
+		Next:          page.Next,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, item2.ID, results[0].ID)
+
+	DropCollection("items")
+}
+
+func TestTypedModelSave(t *testing.T) {
+	fooModel := yamgo.NewTypedModel[models.FooSchema]("foos")
+
+	foo := models.FooSchema{Item: "saved"}
+	id, err := fooModel.Save(&foo)
+	assert.Nil(t, err)
+	assert.Equal(t, foo.ID, id)
+
+	count, err := fooModel.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("foos")
+}
+
+func TestTypedModelBulkAndAggregate(t *testing.T) {
+	fooModel := yamgo.NewTypedModel[models.FooSchema]("foos")
+
+	foo1 := models.FooSchema{ID: primitive.NewObjectID(), Item: "a"}
+	foo2 := models.FooSchema{ID: primitive.NewObjectID(), Item: "b"}
+
+	_, err := fooModel.Bulk().Insert(foo1, foo2).Execute()
+	assert.Nil(t, err)
+
+	results, err := fooModel.Aggregate(mongo.Pipeline{{{Key: "$sort", Value: bson.M{"item": -1}}}})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, foo2.ID, results[0].ID)
+
+	results, _, err = fooModel.PaginatedAggregate("", "", 1, bson.M{"$sort": bson.M{"_id": 1}})
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+
+	result, err := fooModel.FindOneAndPopulate(bson.M{"_id": foo1.ID}, options.FindOptions{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", result.Item)
+
+	_, err = fooModel.FindOneAndPopulate(bson.M{"_id": primitive.NewObjectID()}, options.FindOptions{}, nil)
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	DropCollection("foos")
+}
+
+func TestPaginatedFindRequiresSlicePointer(t *testing.T) {
+	itemModel := models.ItemModel()
+
+	result := models.ItemSchema{}
+	_, err := itemModel.PaginatedFind(yamgo.PaginationFindParams{Query: bson.M{}, Limit: 1}, &result)
+
+	assert.Error(t, err)
+}
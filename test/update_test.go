@@ -0,0 +1,108 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo/test/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestUpdateOne(t *testing.T) {
+	foo := models.FooSchema{ID: primitive.NewObjectID(), Item: "before"}
+	fooModel := models.FooModel()
+
+	_, err := fooModel.InsertOne(&foo)
+	assert.Nil(t, err)
+
+	res, err := fooModel.UpdateOne(bson.M{"_id": foo.ID}, bson.M{"$set": bson.M{"item": "after"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.MatchedCount)
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	result := models.FooSchema{}
+	err = fooModel.FindByObjectID(foo.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, "after", result.Item)
+
+	DropCollection("foos")
+}
+
+func TestUpdateOneUpsert(t *testing.T) {
+	fooModel := models.FooModel()
+	id := primitive.NewObjectID()
+
+	res, err := fooModel.UpdateOne(bson.M{"_id": id}, bson.M{"$set": bson.M{"item": "new"}}, options.Update().SetUpsert(true))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res.MatchedCount)
+	assert.Equal(t, int64(1), res.UpsertedCount)
+	assert.Equal(t, id, res.UpsertedID)
+
+	DropCollection("foos")
+}
+
+func TestUpdateMany(t *testing.T) {
+	fooModel := models.FooModel()
+
+	foos := []interface{}{
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "a"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "a"},
+		models.FooSchema{ID: primitive.NewObjectID(), Item: "b"},
+	}
+	_, err := fooModel.InsertMany(foos)
+	assert.Nil(t, err)
+
+	res, err := fooModel.UpdateMany(bson.M{"item": "a"}, bson.M{"$set": bson.M{"item": "c"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.MatchedCount)
+	assert.Equal(t, int64(2), res.ModifiedCount)
+
+	count, err := fooModel.CountDocuments(bson.M{"item": "c"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	DropCollection("foos")
+}
+
+func TestUpdateByID(t *testing.T) {
+	foo := models.FooSchema{ID: primitive.NewObjectID(), Item: "before"}
+	fooModel := models.FooModel()
+
+	_, err := fooModel.InsertOne(&foo)
+	assert.Nil(t, err)
+
+	res, err := fooModel.UpdateByID(foo.ID.Hex(), bson.M{"$set": bson.M{"item": "after"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	_, err = fooModel.UpdateByID("invalid", bson.M{"$set": bson.M{"item": "after"}})
+	assert.Error(t, err)
+
+	DropCollection("foos")
+}
+
+func TestUpdateWithArrayFilters(t *testing.T) {
+	fooModel := models.FooModel()
+	id := primitive.NewObjectID()
+
+	_, err := fooModel.InsertOne(bson.M{"_id": id, "item": bson.A{1, 5, 10}})
+	assert.Nil(t, err)
+
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"x": bson.M{"$gte": 5}}}})
+	res, err := fooModel.UpdateByObjectID(id, bson.M{"$set": bson.M{"item.$[x]": 0}}, opts)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	result := bson.M{}
+	err = fooModel.FindByObjectID(id, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, bson.A{int32(1), int32(0), int32(0)}, result["item"])
+
+	DropCollection("foos")
+}
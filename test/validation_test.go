@@ -0,0 +1,155 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type addressSchema struct {
+	Zip string `bson:"zip" validate:"len=5"`
+}
+
+type lineSchema struct {
+	Name     string `bson:"name" validate:"required"`
+	Quantity int    `bson:"quantity" validate:"min=1,max=10"`
+}
+
+type validatedSchema struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	Name    string             `bson:"name" validate:"required,max=8"`
+	Status  string             `bson:"status" validate:"enum=draft|published"`
+	Code    string             `bson:"code,omitempty" validate:"regex=^[A-Z]{2,3}$"`
+	Address *addressSchema     `bson:"address,omitempty"`
+	Lines   []lineSchema       `bson:"lines" validate:"min=1"`
+}
+
+func validDocument() validatedSchema {
+	return validatedSchema{
+		ID:     primitive.NewObjectID(),
+		Name:   "order",
+		Status: "draft",
+		Code:   "ABC",
+		Lines:  []lineSchema{{Name: "a", Quantity: 1}},
+	}
+}
+
+func TestValidationOnInsert(t *testing.T) {
+	model := yamgo.NewModel("validated")
+
+	_, err := model.InsertOne(validDocument())
+	assert.Nil(t, err)
+
+	doc := validatedSchema{
+		Status:  "archived",
+		Code:    "abc",
+		Address: &addressSchema{Zip: "123"},
+		Lines:   []lineSchema{{Quantity: 11}},
+	}
+
+	_, err = model.InsertOne(doc)
+	assert.True(t, errors.Is(err, yamgo.ErrValidation))
+
+	var validationErr *yamgo.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+
+	fields := []string{}
+	for _, field := range validationErr.Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(t, []string{"name", "status", "code", "address.zip", "lines.0.name", "lines.0.quantity"}, fields)
+
+	count, err := model.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("validated")
+}
+
+func TestValidationOnInsertMany(t *testing.T) {
+	model := yamgo.NewModel("validated")
+
+	invalid := validDocument()
+	invalid.Lines = nil
+
+	_, err := model.InsertMany([]interface{}{validDocument(), invalid})
+	assert.True(t, errors.Is(err, yamgo.ErrValidation))
+
+	count, err := model.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	DropCollection("validated")
+}
+
+func TestValidationOnSaveAndBulk(t *testing.T) {
+	model := yamgo.NewModel("validated")
+
+	doc := validDocument()
+	_, err := model.Save(&doc)
+	assert.Nil(t, err)
+
+	doc.Name = "a very long name"
+	_, err = model.Save(&doc)
+	assert.True(t, errors.Is(err, yamgo.ErrValidation))
+
+	_, err = model.Bulk().
+		Insert(validDocument()).
+		ReplaceOne(bson.M{"_id": doc.ID}, doc).
+		Execute()
+	assert.True(t, errors.Is(err, yamgo.ErrValidation))
+
+	count, err := model.CountDocuments(bson.M{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	DropCollection("validated")
+}
+
+type requiredStampSchema struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt" yamgo:"createdAt" validate:"required"`
+	UpdatedAt time.Time          `bson:"updatedAt" yamgo:"updatedAt" validate:"required"`
+}
+
+func TestValidationAfterTimestamps(t *testing.T) {
+	model := yamgo.NewModel("validated")
+
+	doc := requiredStampSchema{}
+	_, err := model.Save(&doc)
+	assert.Nil(t, err)
+
+	_, err = model.ReplaceOne(bson.M{"_id": doc.ID}, doc)
+	assert.Nil(t, err)
+
+	_, err = model.Bulk().Insert(requiredStampSchema{ID: primitive.NewObjectID()}).Execute()
+	assert.Nil(t, err)
+
+	DropCollection("validated")
+}
+
+func TestCustomValidator(t *testing.T) {
+	model := yamgo.NewModel("validated", yamgo.WithValidator(func(doc interface{}) error {
+		if m, ok := doc.(bson.M); ok && m["sku"] == nil {
+			return &yamgo.ValidationError{Fields: []yamgo.FieldError{{Field: "sku", Rule: "custom", Message: "is required"}}}
+		}
+		return nil
+	}))
+
+	_, err := model.InsertOne(bson.M{"name": "a"})
+
+	var validationErr *yamgo.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "validated", validationErr.Collection)
+	assert.Equal(t, "sku", validationErr.Fields[0].Field)
+
+	_, err = model.InsertOne(bson.M{"name": "a", "sku": "A1"})
+	assert.Nil(t, err)
+
+	DropCollection("validated")
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wezard-it/yamgo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type versionedSchema struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	Name    string             `bson:"name"`
+	Version int64              `bson:"__v"`
+}
+
+func TestVersionIncrementsOnSave(t *testing.T) {
+	model := yamgo.NewModel("versioned", yamgo.WithVersioning())
+
+	doc := versionedSchema{Name: "a"}
+	_, err := model.Save(&doc)
+	assert.Nil(t, err)
+
+	doc.Name = "b"
+	_, err = model.Save(&doc)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), doc.Version)
+
+	result := versionedSchema{}
+	err = model.FindByObjectID(doc.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", result.Name)
+	assert.Equal(t, int64(1), result.Version)
+
+	DropCollection("versioned")
+}
+
+func TestVersionConflictOnStaleReplace(t *testing.T) {
+	model := yamgo.NewModel("versioned", yamgo.WithVersioning())
+
+	doc := versionedSchema{ID: primitive.NewObjectID(), Name: "a"}
+	_, err := model.InsertOne(doc)
+	assert.Nil(t, err)
+
+	first, second := doc, doc
+
+	first.Name = "b"
+	_, err = model.ReplaceOne(bson.M{"_id": doc.ID}, &first)
+	assert.Nil(t, err)
+
+	second.Name = "c"
+	_, err = model.ReplaceOne(bson.M{"_id": doc.ID}, &second)
+	assert.True(t, errors.Is(err, yamgo.ErrVersionConflict))
+	assert.Equal(t, int64(0), second.Version)
+
+	result := versionedSchema{}
+	err = model.FindByObjectID(doc.ID, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", result.Name)
+
+	DropCollection("versioned")
+}
+
+func TestVersionConflictOnUpdate(t *testing.T) {
+	model := yamgo.NewModel("versioned", yamgo.WithVersioning())
+
+	doc := versionedSchema{ID: primitive.NewObjectID(), Name: "a"}
+	_, err := model.InsertOne(doc)
+	assert.Nil(t, err)
+
+	res, err := model.UpdateOne(bson.M{"_id": doc.ID, "__v": 0}, bson.M{"$set": bson.M{"name": "b"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	_, err = model.UpdateOne(bson.M{"_id": doc.ID, "__v": 0}, bson.M{"$set": bson.M{"name": "c"}})
+	assert.True(t, errors.Is(err, yamgo.ErrVersionConflict))
+
+	_, err = model.UpdateOne(bson.M{"_id": primitive.NewObjectID(), "__v": 0}, bson.M{"$set": bson.M{"name": "c"}})
+	assert.Nil(t, err)
+
+	DropCollection("versioned")
+}
+
+func TestVersionInBulkReplace(t *testing.T) {
+	model := yamgo.NewModel("versioned", yamgo.WithVersioning())
+
+	doc := versionedSchema{ID: primitive.NewObjectID(), Name: "a"}
+	_, err := model.InsertOne(doc)
+	assert.Nil(t, err)
+
+	// Queued but never executed.
+	doc.Name = "b"
+	model.Bulk().ReplaceOne(bson.M{"_id": doc.ID}, &doc)
+	assert.Equal(t, int64(0), doc.Version)
+
+	// Failed before reaching the replace.
+	_, err = model.Bulk().
+		Insert(versionedSchema{ID: doc.ID}).
+		ReplaceOne(bson.M{"_id": doc.ID}, &doc).
+		Execute()
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), doc.Version)
+
+	_, err = model.Bulk().ReplaceOne(bson.M{"_id": doc.ID}, &doc).Execute()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), doc.Version)
+
+	_, err = model.Save(&doc)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), doc.Version)
+
+	DropCollection("versioned")
+}
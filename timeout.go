@@ -0,0 +1,150 @@
+package yamgo
+
+import (
+	"context"
+	"time"
+)
+
+// OperationKind identifies the kind of operation a model runs.
+type OperationKind string
+
+const (
+	OperationFindOne    OperationKind = "findOne"
+	OperationFind       OperationKind = "find"
+	OperationAggregate  OperationKind = "aggregate"
+	OperationCount      OperationKind = "count"
+	OperationDistinct   OperationKind = "distinct"
+	OperationInsertOne  OperationKind = "insertOne"
+	OperationInsertMany OperationKind = "insertMany"
+	OperationUpdateOne  OperationKind = "updateOne"
+	OperationUpdateMany OperationKind = "updateMany"
+	OperationDeleteOne  OperationKind = "deleteOne"
+	OperationDeleteMany OperationKind = "deleteMany"
+	OperationReplaceOne OperationKind = "replaceOne"
+
+	OperationFindOneAndUpdate  OperationKind = "findOneAndUpdate"
+	OperationFindOneAndReplace OperationKind = "findOneAndReplace"
+	OperationFindOneAndDelete  OperationKind = "findOneAndDelete"
+
+	OperationExists             OperationKind = "exists"
+	OperationPaginatedFind      OperationKind = "paginatedFind"
+	OperationPaginatedAggregate OperationKind = "paginatedAggregate"
+
+	OperationFindEach      OperationKind = "findEach"
+	OperationAggregateEach OperationKind = "aggregateEach"
+	OperationFindStream    OperationKind = "findStream"
+
+	OperationBulkWrite OperationKind = "bulkWrite"
+	OperationStream    OperationKind = "stream"
+	OperationCollMod   OperationKind = "collMod"
+	OperationIndexes   OperationKind = "indexes"
+)
+
+// ModelOption configures a model created by NewModel.
+type ModelOption func(*Model)
+
+var defaultTimeouts = map[OperationKind]time.Duration{
+	OperationFindOne:    MediumTimeout * time.Second,
+	OperationFind:       LongTimeout * time.Second,
+	OperationAggregate:  LongTimeout * time.Second,
+	OperationCount:      LongTimeout * time.Second,
+	OperationDistinct:   LongTimeout * time.Second,
+	OperationInsertOne:  MediumTimeout * time.Second,
+	OperationInsertMany: LongTimeout * time.Second,
+	OperationUpdateOne:  MediumTimeout * time.Second,
+	OperationUpdateMany: LongTimeout * time.Second,
+	OperationDeleteOne:  MediumTimeout * time.Second,
+	OperationDeleteMany: LongTimeout * time.Second,
+	OperationReplaceOne: MediumTimeout * time.Second,
+
+	OperationFindOneAndUpdate:  MediumTimeout * time.Second,
+	OperationFindOneAndReplace: MediumTimeout * time.Second,
+	OperationFindOneAndDelete:  MediumTimeout * time.Second,
+
+	OperationPaginatedFind:      LongTimeout * time.Second,
+	OperationPaginatedAggregate: LongTimeout * time.Second,
+
+	OperationBulkWrite: LongTimeout * time.Second,
+	OperationCollMod:   LongTimeout * time.Second,
+	OperationIndexes:   LongTimeout * time.Second,
+	// Streams feed long running exports, so they are only bounded by the
+	// caller context unless a timeout is configured.
+	OperationStream: 0,
+}
+
+// Exists and the streaming reads used the FindOne and Stream timeouts before
+// they had kinds of their own, and keep using them unless a timeout is
+// configured for their kind.
+var timeoutFallbacks = map[OperationKind]OperationKind{
+	OperationExists:        OperationFindOne,
+	OperationFindEach:      OperationStream,
+	OperationAggregateEach: OperationStream,
+	OperationFindStream:    OperationStream,
+}
+
+// WithOperationTimeout sets the default timeout of one kind of operation.
+func WithOperationTimeout(kind OperationKind, timeout time.Duration) ModelOption {
+	return func(mf *Model) {
+		timeouts := make(map[OperationKind]time.Duration, len(mf.timeouts)+1)
+		for k, v := range mf.timeouts {
+			timeouts[k] = v
+		}
+		timeouts[kind] = timeout
+		mf.timeouts = timeouts
+	}
+}
+
+// WithDefaultTimeout sets the timeout of every operation kind that has no
+// timeout of its own.
+func WithDefaultTimeout(timeout time.Duration) ModelOption {
+	return func(mf *Model) {
+		mf.defaultTimeout = timeout
+	}
+}
+
+// WithTimeout returns a copy of the model whose operations all use timeout,
+// overriding the model defaults for a single call. When the model context has
+// a deadline too, the earlier of the two applies.
+func (mf *Model) WithTimeout(timeout time.Duration) *Model {
+	model := *mf
+	model.timeout = timeout
+	return &model
+}
+
+// It resolves the timeout of an operation: a per call override wins over the
+// model configuration, which wins over the package defaults.
+func (mf *Model) operationTimeout(kind OperationKind) time.Duration {
+	if mf.timeout > 0 {
+		return mf.timeout
+	}
+
+	if timeout, ok := mf.timeouts[kind]; ok {
+		return timeout
+	}
+
+	if fallback, ok := timeoutFallbacks[kind]; ok {
+		return mf.operationTimeout(fallback)
+	}
+
+	if mf.defaultTimeout > 0 {
+		return mf.defaultTimeout
+	}
+
+	return defaultTimeouts[kind]
+}
+
+// It returns the time left before the context deadline, which is forwarded to
+// the server as maxTimeMS so it stops working once the client gives up.
+func maxTime(ctx context.Context) *time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	remaining := time.Until(deadline)
+	if remaining < time.Millisecond {
+		remaining = time.Millisecond
+	}
+
+	return &remaining
+}
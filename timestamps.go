@@ -0,0 +1,277 @@
+package yamgo
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultCreatedAtField = "createdAt"
+	DefaultUpdatedAtField = "updatedAt"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateTimeType = reflect.TypeOf(primitive.DateTime(0))
+)
+
+// WithTimestamps makes the model set the updatedAt field on inserts, updates
+// and replaces, and the createdAt field on inserts and upserts.
+func WithTimestamps() ModelOption {
+	return WithTimestampFields(DefaultCreatedAtField, DefaultUpdatedAtField)
+}
+
+// WithTimestampFields is like WithTimestamps with custom field names. An
+// empty name disables that timestamp.
+func WithTimestampFields(createdField string, updatedField string) ModelOption {
+	return func(mf *Model) {
+		mf.createdField = createdField
+		mf.updatedField = updatedField
+	}
+}
+
+// WithClock replaces time.Now as the source of timestamps, e.g. in tests.
+func WithClock(clock func() time.Time) ModelOption {
+	return func(mf *Model) {
+		mf.clock = clock
+	}
+}
+
+func (mf *Model) now() time.Time {
+	if mf.clock != nil {
+		return mf.clock()
+	}
+
+	return time.Now().UTC()
+}
+
+// It sets the timestamps of a document about to be inserted or replaced: the
+// update timestamp always, the creation one only when created is set and it is
+// still empty. Inserts and upserting replaces, which may insert, set created;
+// a plain replace stores the creation time the replacement carries. Struct
+// values are copied, so the returned document must be used in place of doc.
+func (mf *Model) stampDocument(doc interface{}, created bool) interface{} {
+	now := mf.now()
+
+	createdField := mf.createdField
+	if !created {
+		createdField = ""
+	}
+
+	switch d := doc.(type) {
+	case bson.M:
+		if createdField == "" && mf.updatedField == "" {
+			return doc
+		}
+		if _, ok := d[createdField]; createdField != "" && !ok {
+			d[createdField] = now
+		}
+		if mf.updatedField != "" {
+			d[mf.updatedField] = now
+		}
+		return d
+	case bson.D:
+		if createdField == "" && mf.updatedField == "" {
+			return doc
+		}
+		stamped := make(bson.D, 0, len(d)+2)
+		hasCreated := false
+		for _, elem := range d {
+			if elem.Key == mf.updatedField {
+				continue
+			}
+			hasCreated = hasCreated || elem.Key == createdField
+			stamped = append(stamped, elem)
+		}
+		if createdField != "" && !hasCreated {
+			stamped = append(stamped, bson.E{Key: createdField, Value: now})
+		}
+		if mf.updatedField != "" {
+			stamped = append(stamped, bson.E{Key: mf.updatedField, Value: now})
+		}
+		return stamped
+	}
+
+	val := reflect.ValueOf(doc)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() || val.Elem().Kind() != reflect.Struct {
+			return doc
+		}
+		mf.stampStruct(val.Elem(), now, created)
+		return doc
+	}
+
+	if val.Kind() != reflect.Struct {
+		return doc
+	}
+
+	copied := reflect.New(val.Type()).Elem()
+	copied.Set(val)
+	if !mf.stampStruct(copied, now, created) {
+		return doc
+	}
+
+	return copied.Interface()
+}
+
+// It fills the timestamp fields of a struct, found by their yamgo tag or by
+// the configured bson names, and reports whether any was set.
+func (mf *Model) stampStruct(val reflect.Value, now time.Time, created bool) bool {
+	stamped := false
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key, inline := bsonKey(field)
+		if inline && field.Type.Kind() == reflect.Struct {
+			stamped = mf.stampStruct(val.Field(i), now, created) || stamped
+			continue
+		}
+
+		role := timestampRole(field)
+		if role == "" {
+			switch key {
+			case mf.createdField:
+				role = DefaultCreatedAtField
+			case mf.updatedField:
+				role = DefaultUpdatedAtField
+			}
+		}
+
+		fieldVal := val.Field(i)
+		switch role {
+		case DefaultCreatedAtField:
+			if created && fieldVal.IsZero() {
+				stamped = setTime(fieldVal, now) || stamped
+			}
+		case DefaultUpdatedAtField:
+			stamped = setTime(fieldVal, now) || stamped
+		}
+	}
+
+	return stamped
+}
+
+// It returns "createdAt" or "updatedAt" for fields tagged as such.
+func timestampRole(field reflect.StructField) string {
+	for _, opt := range strings.Split(field.Tag.Get("yamgo"), ",") {
+		if opt == DefaultCreatedAtField || opt == DefaultUpdatedAtField {
+			return opt
+		}
+	}
+
+	return ""
+}
+
+func setTime(field reflect.Value, now time.Time) bool {
+	switch {
+	case field.Type() == timeType:
+		field.Set(reflect.ValueOf(now))
+	case field.Type() == dateTimeType:
+		field.Set(reflect.ValueOf(primitive.NewDateTimeFromTime(now)))
+	case field.Kind() == reflect.Ptr && field.Type().Elem() == timeType:
+		field.Set(reflect.ValueOf(&now))
+	case field.Kind() == reflect.Interface:
+		field.Set(reflect.ValueOf(now))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// It adds the update timestamp to an update document with $set, and the
+// creation timestamp with $setOnInsert so upserts get one too. Fields the
+// update already sets are left alone.
+func (mf *Model) stampUpdate(update interface{}) interface{} {
+	if mf.createdField == "" && mf.updatedField == "" {
+		return update
+	}
+
+	stamped, ok := copyUpdate(update)
+	if !ok {
+		return update
+	}
+
+	now := mf.now()
+
+	if mf.updatedField != "" && !updateSets(stamped, mf.updatedField) {
+		stamped["$set"] = withField(stamped["$set"], mf.updatedField, now)
+	}
+
+	if mf.createdField != "" && !updateSets(stamped, mf.createdField) {
+		stamped["$setOnInsert"] = withField(stamped["$setOnInsert"], mf.createdField, now)
+	}
+
+	return stamped
+}
+
+// It copies an update document made of operators into a map. Pipelines and
+// other kinds of updates are not supported.
+func copyUpdate(update interface{}) (bson.M, bool) {
+	switch u := update.(type) {
+	case bson.M:
+		copied := make(bson.M, len(u)+2)
+		for key, value := range u {
+			copied[key] = value
+		}
+		return copied, true
+	case bson.D:
+		copied := make(bson.M, len(u)+2)
+		for _, elem := range u {
+			copied[elem.Key] = elem.Value
+		}
+		return copied, true
+	default:
+		return nil, false
+	}
+}
+
+// It reports whether any update operator already targets field.
+func updateSets(update bson.M, field string) bool {
+	for _, operand := range update {
+		switch o := operand.(type) {
+		case bson.M:
+			if _, ok := o[field]; ok {
+				return true
+			}
+		case bson.D:
+			for _, elem := range o {
+				if elem.Key == field {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// It copies an operator document adding one field to it.
+func withField(operand interface{}, field string, value interface{}) interface{} {
+	switch o := operand.(type) {
+	case bson.M:
+		copied := make(bson.M, len(o)+1)
+		for key, v := range o {
+			copied[key] = v
+		}
+		copied[field] = value
+		return copied
+	case bson.D:
+		copied := make(bson.D, len(o), len(o)+1)
+		copy(copied, o)
+		return append(copied, bson.E{Key: field, Value: value})
+	case nil:
+		return bson.M{field: value}
+	default:
+		return operand
+	}
+}
@@ -0,0 +1,345 @@
+package yamgo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TypedModel is a Model bound to the schema type T. Its find methods return
+// values of T and its inserts only accept T, so misuse is a compile error.
+// The operations without a typed form, e.g. index management, are reached
+// through Model.
+type TypedModel[T any] struct {
+	model Model
+}
+
+// TypedBulk is a Bulk whose inserts and replacements only accept T.
+type TypedBulk[T any] struct {
+	bulk *Bulk
+}
+
+// NewTypedModel creates a typed model on the default client.
+func NewTypedModel[T any](collectionName string, opts ...ModelOption) TypedModel[T] {
+	return Typed[T](NewModel(collectionName, opts...))
+}
+
+// Typed binds an existing model, e.g. one created on a Client, to T.
+func Typed[T any](model Model) TypedModel[T] {
+	return TypedModel[T]{model: model}
+}
+
+// Model returns the untyped model the typed model runs on.
+func (tm *TypedModel[T]) Model() *Model {
+	return &tm.model
+}
+
+// Use adds middleware around the operations of the model.
+func (tm *TypedModel[T]) Use(middleware ...Middleware) {
+	tm.model.Use(middleware...)
+}
+
+func (tm *TypedModel[T]) WithContext(ctx context.Context) *TypedModel[T] {
+	return &TypedModel[T]{model: *tm.model.WithContext(ctx)}
+}
+
+func (tm *TypedModel[T]) WithTimeout(timeout time.Duration) *TypedModel[T] {
+	return &TypedModel[T]{model: *tm.model.WithTimeout(timeout)}
+}
+
+func (tm *TypedModel[T]) WithDeleted() *TypedModel[T] {
+	return &TypedModel[T]{model: *tm.model.WithDeleted()}
+}
+
+func (tm *TypedModel[T]) OnlyDeleted() *TypedModel[T] {
+	return &TypedModel[T]{model: *tm.model.OnlyDeleted()}
+}
+
+func (tm *TypedModel[T]) FindOne(filter bson.M) (T, error) {
+	var result T
+	err := tm.model.FindOne(filter, &result)
+	return result, err
+}
+
+func (tm *TypedModel[T]) FindByID(id string) (T, error) {
+	var result T
+	err := tm.model.FindByID(id, &result)
+	return result, err
+}
+
+func (tm *TypedModel[T]) FindByObjectID(objectID primitive.ObjectID) (T, error) {
+	var result T
+	err := tm.model.FindByObjectID(objectID, &result)
+	return result, err
+}
+
+func (tm *TypedModel[T]) FindByIDs(ids []string) ([]T, FindByIDsResult, error) {
+	results := []T{}
+	report, err := tm.model.FindByIDs(ids, &results)
+	return results, report, err
+}
+
+func (tm *TypedModel[T]) FindByObjectIDs(ids []primitive.ObjectID) ([]T, FindByIDsResult, error) {
+	results := []T{}
+	report, err := tm.model.FindByObjectIDs(ids, &results)
+	return results, report, err
+}
+
+func (tm *TypedModel[T]) Find(filter bson.M) ([]T, error) {
+	results := []T{}
+	err := tm.model.Find(filter, &results)
+	return results, err
+}
+
+func (tm *TypedModel[T]) FindWithOptions(filter bson.M, option options.FindOptions) ([]T, error) {
+	results := []T{}
+	err := tm.model.FindWithOptions(filter, option, &results)
+	return results, err
+}
+
+func (tm *TypedModel[T]) PaginatedFind(params PaginationFindParams) ([]T, Page, error) {
+	results := []T{}
+	page, err := tm.model.PaginatedFind(params, &results)
+	return results, page, err
+}
+
+func (tm *TypedModel[T]) PaginatedAggregate(prevCursor string, nextCursor string, limit int64, pipeline ...interface{}) ([]T, Page, error) {
+	raws := []bson.Raw{}
+	page, err := tm.model.PaginatedAggregate(&raws, prevCursor, nextCursor, limit, pipeline...)
+	if err != nil {
+		return nil, page, err
+	}
+
+	results := make([]T, len(raws))
+	for i, raw := range raws {
+		if err = bson.Unmarshal(raw, &results[i]); err != nil {
+			return nil, page, err
+		}
+	}
+
+	return results, page, nil
+}
+
+func (tm *TypedModel[T]) FindAndPopulate(filter bson.M, option options.FindOptions, populate []PopulateOptions) ([]T, error) {
+	results := []T{}
+	err := tm.model.FindAndPopulate(filter, option, populate, &results)
+	return results, err
+}
+
+// FindOneAndPopulate returns the first populated document matching filter,
+// or mongo.ErrNoDocuments.
+func (tm *TypedModel[T]) FindOneAndPopulate(filter bson.M, option options.FindOptions, populate []PopulateOptions) (T, error) {
+	var result T
+	results := []T{}
+	if err := tm.model.FindOneAndPopulate(filter, option, populate, &results); err != nil {
+		return result, err
+	}
+	if len(results) == 0 {
+		return result, mongo.ErrNoDocuments
+	}
+	return results[0], nil
+}
+
+func (tm *TypedModel[T]) Aggregate(pipeline mongo.Pipeline) ([]T, error) {
+	results := []T{}
+	err := tm.model.Aggregate(pipeline, &results)
+	return results, err
+}
+
+func (tm *TypedModel[T]) Distinct(field string, filter bson.M, results interface{}, opts ...*options.DistinctOptions) error {
+	return tm.model.Distinct(field, filter, results, opts...)
+}
+
+func (tm *TypedModel[T]) CountDocuments(filter bson.M, opts ...*options.CountOptions) (int, error) {
+	return tm.model.CountDocuments(filter, opts...)
+}
+
+func (tm *TypedModel[T]) EstimatedDocumentCount() (int, error) {
+	return tm.model.EstimatedDocumentCount()
+}
+
+func (tm *TypedModel[T]) Exists(filter bson.M) (bool, error) {
+	return tm.model.Exists(filter)
+}
+
+func (tm *TypedModel[T]) FindOneAndUpdate(filter bson.M, update interface{}, opts ...*options.FindOneAndUpdateOptions) (T, error) {
+	var result T
+	err := tm.model.FindOneAndUpdate(filter, update, &result, opts...)
+	return result, err
+}
+
+func (tm *TypedModel[T]) FindOneAndReplace(filter bson.M, replacement T, opts ...*options.FindOneAndReplaceOptions) (T, error) {
+	var result T
+	err := tm.model.FindOneAndReplace(filter, replacement, &result, opts...)
+	return result, err
+}
+
+func (tm *TypedModel[T]) FindOneAndDelete(filter bson.M, opts ...*options.FindOneAndDeleteOptions) (T, error) {
+	var result T
+	err := tm.model.FindOneAndDelete(filter, &result, opts...)
+	return result, err
+}
+
+func (tm *TypedModel[T]) InsertOne(doc T) (*mongo.InsertOneResult, error) {
+	return tm.model.InsertOne(doc)
+}
+
+func (tm *TypedModel[T]) InsertMany(docs []T, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	records := make([]interface{}, len(docs))
+	for i := range docs {
+		records[i] = docs[i]
+	}
+	return tm.model.InsertMany(records, opts...)
+}
+
+func (tm *TypedModel[T]) ReplaceOne(filter bson.M, replacement T, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	return tm.model.ReplaceOne(filter, replacement, opts...)
+}
+
+func (tm *TypedModel[T]) UpdateOne(filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return tm.model.UpdateOne(filter, update, opts...)
+}
+
+func (tm *TypedModel[T]) UpdateMany(filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return tm.model.UpdateMany(filter, update, opts...)
+}
+
+func (tm *TypedModel[T]) UpdateByID(id string, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return tm.model.UpdateByID(id, update, opts...)
+}
+
+func (tm *TypedModel[T]) UpdateByObjectID(objectID primitive.ObjectID, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return tm.model.UpdateByObjectID(objectID, update, opts...)
+}
+
+func (tm *TypedModel[T]) DeleteOne(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return tm.model.DeleteOne(filter, opts...)
+}
+
+func (tm *TypedModel[T]) DeleteMany(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return tm.model.DeleteMany(filter, opts...)
+}
+
+func (tm *TypedModel[T]) DeleteByID(id string, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return tm.model.DeleteByID(id, opts...)
+}
+
+func (tm *TypedModel[T]) DeleteByObjectID(objectID primitive.ObjectID, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return tm.model.DeleteByObjectID(objectID, opts...)
+}
+
+func (tm *TypedModel[T]) Restore(filter bson.M) (*mongo.UpdateResult, error) {
+	return tm.model.Restore(filter)
+}
+
+func (tm *TypedModel[T]) HardDelete(filter bson.M, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return tm.model.HardDelete(filter, opts...)
+}
+
+// Save works like Model.Save; the generated _id is set on doc.
+func (tm *TypedModel[T]) Save(doc *T) (interface{}, error) {
+	return tm.model.Save(doc)
+}
+
+// FindEach decodes every document matching filter into T and passes it to fn.
+func (tm *TypedModel[T]) FindEach(filter bson.M, fn func(doc T) error, opts ...*options.FindOptions) error {
+	return tm.model.FindEach(filter, tm.decodeEach(fn, true), opts...)
+}
+
+// AggregateEach decodes every document of the pipeline into T and passes it
+// to fn.
+func (tm *TypedModel[T]) AggregateEach(pipeline mongo.Pipeline, fn func(doc T) error, opts ...*options.AggregateOptions) error {
+	return tm.model.AggregateEach(pipeline, tm.decodeEach(fn, false), opts...)
+}
+
+// FindAndPopulateEach decodes every populated document into T and passes it
+// to fn.
+func (tm *TypedModel[T]) FindAndPopulateEach(filter bson.M, option options.FindOptions, populate []PopulateOptions, fn func(doc T) error) error {
+	return tm.model.FindAndPopulateEach(filter, option, populate, tm.decodeEach(fn, true))
+}
+
+// It decodes the raw documents of a stream into T, running the find hooks
+// when the documents are the ones of the collection.
+func (tm *TypedModel[T]) decodeEach(fn func(doc T) error, hooks bool) func(raw bson.Raw) error {
+	return func(raw bson.Raw) error {
+		var doc T
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return err
+		}
+		if hooks {
+			if err := tm.model.afterFind(&doc); err != nil {
+				return err
+			}
+		}
+		return fn(doc)
+	}
+}
+
+// Bulk returns an empty ordered bulk builder for the model.
+func (tm *TypedModel[T]) Bulk() *TypedBulk[T] {
+	return &TypedBulk[T]{bulk: tm.model.Bulk()}
+}
+
+func (b *TypedBulk[T]) Ordered(ordered bool) *TypedBulk[T] {
+	b.bulk.Ordered(ordered)
+	return b
+}
+
+func (b *TypedBulk[T]) ChunkSize(size int) *TypedBulk[T] {
+	b.bulk.ChunkSize(size)
+	return b
+}
+
+func (b *TypedBulk[T]) Insert(docs ...T) *TypedBulk[T] {
+	for _, doc := range docs {
+		b.bulk.Insert(doc)
+	}
+	return b
+}
+
+func (b *TypedBulk[T]) UpdateOne(filter bson.M, update interface{}) *TypedBulk[T] {
+	b.bulk.UpdateOne(filter, update)
+	return b
+}
+
+func (b *TypedBulk[T]) UpdateMany(filter bson.M, update interface{}) *TypedBulk[T] {
+	b.bulk.UpdateMany(filter, update)
+	return b
+}
+
+func (b *TypedBulk[T]) UpsertOne(filter bson.M, update interface{}) *TypedBulk[T] {
+	b.bulk.UpsertOne(filter, update)
+	return b
+}
+
+func (b *TypedBulk[T]) ReplaceOne(filter bson.M, replacement T) *TypedBulk[T] {
+	b.bulk.ReplaceOne(filter, replacement)
+	return b
+}
+
+func (b *TypedBulk[T]) UpsertReplace(filter bson.M, replacement T) *TypedBulk[T] {
+	b.bulk.UpsertReplace(filter, replacement)
+	return b
+}
+
+func (b *TypedBulk[T]) DeleteOne(filter bson.M) *TypedBulk[T] {
+	b.bulk.DeleteOne(filter)
+	return b
+}
+
+func (b *TypedBulk[T]) DeleteMany(filter bson.M) *TypedBulk[T] {
+	b.bulk.DeleteMany(filter)
+	return b
+}
+
+func (b *TypedBulk[T]) Len() int {
+	return b.bulk.Len()
+}
+
+func (b *TypedBulk[T]) Execute() (BulkResult, error) {
+	return b.bulk.Execute()
+}
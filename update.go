@@ -0,0 +1,92 @@
+package yamgo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateOne updates the first document matching filter. On versioned models
+// a filter on the version key that no longer matches fails with
+// ErrVersionConflict.
+func (mf *Model) UpdateOne(filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	op := &Operation{Kind: OperationUpdateOne, Filter: filter, Update: update, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.UpdateOptions)
+		op.Result, err = model.updateOne(op.Filter, op.Update, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.UpdateResult)
+
+	return res, err
+}
+
+func (mf *Model) updateOne(filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+
+	ctx, cancel := mf.newContext(OperationUpdateOne)
+
+	defer cancel()
+
+	res, err := mf.col.UpdateOne(ctx, mf.scope(filter), mf.prepareUpdate(update), opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mf.checkVersion(filter, res.MatchedCount > 0 || res.UpsertedCount > 0); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (mf *Model) UpdateMany(filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	op := &Operation{Kind: OperationUpdateMany, Filter: filter, Update: update, Options: opts}
+
+	err := mf.run(op, func(model *Model, op *Operation) (err error) {
+		opts, _ := op.Options.([]*options.UpdateOptions)
+		op.Result, err = model.updateMany(op.Filter, op.Update, opts...)
+		return err
+	})
+
+	res, _ := op.Result.(*mongo.UpdateResult)
+
+	return res, err
+}
+
+func (mf *Model) updateMany(filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+
+	ctx, cancel := mf.newContext(OperationUpdateMany)
+
+	defer cancel()
+
+	res, err := mf.col.UpdateMany(ctx, mf.scope(filter), mf.prepareUpdate(update), opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (mf *Model) UpdateByID(id string, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mf.UpdateByObjectID(objectID, update, opts...)
+}
+
+func (mf *Model) UpdateByObjectID(objectID primitive.ObjectID, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return mf.UpdateOne(bson.M{"_id": objectID}, update, opts...)
+}
+
+// It applies the model timestamps and versioning to an update document.
+func (mf *Model) prepareUpdate(update interface{}) interface{} {
+	return mf.versionUpdate(mf.stampUpdate(update))
+}
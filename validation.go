@@ -0,0 +1,310 @@
+package yamgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrValidation is matched by every *ValidationError.
+var ErrValidation = errors.New("validation failed")
+
+type (
+	// FieldError describes a field that failed a validation rule. Field is
+	// the bson path of the field, e.g. "address.zip" or "items.2.name".
+	FieldError struct {
+		Field   string
+		Rule    string
+		Message string
+	}
+
+	// ValidationError is returned by writes whose document failed validation,
+	// before anything is sent to the server. It lists every failing field.
+	ValidationError struct {
+		Collection string
+		Fields     []FieldError
+	}
+
+	// ValidatorFunc validates a document before it is written. Returning a
+	// *ValidationError adds its fields to the ones of the struct tags, any
+	// other error aborts the write as is.
+	ValidatorFunc func(doc interface{}) error
+)
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Error())
+	}
+	return fmt.Sprintf("validation failed in %s: %s", e.Collection, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// WithValidator adds a custom validation to the documents inserted or
+// replaced through the model, run after the validate struct tags.
+func WithValidator(validator ValidatorFunc) ModelOption {
+	return func(mf *Model) {
+		mf.validators = append(mf.validators, validator)
+	}
+}
+
+// It validates a document about to be inserted or replaced against its
+// validate struct tags and the model validators.
+//
+// The tag lists comma separated rules:
+//
+//	required    the field is not empty
+//	min=N       numbers are at least N, strings, slices and maps have at least N elements
+//	max=N       numbers are at most N, strings, slices and maps have at most N elements
+//	len=N       strings, slices and maps have exactly N elements
+//	enum=a|b|c  the field is one of the values
+//	regex=EXPR  strings match EXPR; it must be the last rule, as EXPR may contain commas
+//
+// Nested structs, pointers to structs and slices of structs are validated too.
+func (mf *Model) validate(doc interface{}) error {
+	fields := []FieldError{}
+
+	if _, isMap := doc.(bson.M); !isMap {
+		if val, err := documentStruct(doc); err == nil {
+			fields = validateStruct(val, "", fields)
+		}
+	}
+
+	for _, validator := range mf.validators {
+		err := validator(doc)
+		if err == nil {
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+		fields = append(fields, validationErr.Fields...)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Collection: mf.col.Name(), Fields: fields}
+}
+
+func validateStruct(val reflect.Value, prefix string, fields []FieldError) []FieldError {
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("bson") == "-" {
+			continue
+		}
+
+		key, inline := bsonKey(field)
+		if inline && field.Type.Kind() == reflect.Struct {
+			fields = validateStruct(val.Field(i), prefix, fields)
+			continue
+		}
+
+		path := prefix + key
+		fieldVal := val.Field(i)
+
+		if tag, ok := field.Tag.Lookup("validate"); ok && tag != "" && tag != "-" {
+			fields = validateField(fieldVal, path, tag, fields)
+		}
+
+		fields = validateNested(fieldVal, path, fields)
+	}
+
+	return fields
+}
+
+// It descends into the structs held by a field.
+func validateNested(val reflect.Value, path string, fields []FieldError) []FieldError {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return fields
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		fields = validateStruct(val, path+".", fields)
+	case reflect.Slice, reflect.Array:
+		elem := val.Type().Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct && elem.Kind() != reflect.Interface {
+			return fields
+		}
+		for i := 0; i < val.Len(); i++ {
+			fields = validateNested(val.Index(i), path+"."+strconv.Itoa(i), fields)
+		}
+	}
+
+	return fields
+}
+
+func validateField(val reflect.Value, path string, tag string, fields []FieldError) []FieldError {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			// Only required applies to a missing value.
+			if hasRule(tag, "required") {
+				fields = append(fields, FieldError{Field: path, Rule: "required", Message: "is required"})
+			}
+			return fields
+		}
+		val = val.Elem()
+	}
+
+	for _, rule := range splitRules(tag) {
+		name, param, _ := strings.Cut(rule, "=")
+
+		if message, ok := checkRule(val, name, param); !ok {
+			fields = append(fields, FieldError{Field: path, Rule: name, Message: message})
+			if name == "required" {
+				break
+			}
+		}
+	}
+
+	return fields
+}
+
+func hasRule(tag string, name string) bool {
+	for _, rule := range splitRules(tag) {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+func splitRules(tag string) []string {
+	rules := []string{}
+
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			return append(rules, tag)
+		}
+
+		rule, rest, _ := strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+		tag = rest
+	}
+
+	return rules
+}
+
+// It checks a single rule and returns the failure message.
+func checkRule(val reflect.Value, name string, param string) (string, bool) {
+	switch name {
+	case "required":
+		return "is required", !val.IsZero()
+
+	case "min", "max", "len":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return fmt.Sprintf("has an invalid %s rule %q", name, param), false
+		}
+
+		size, isLength, ok := measure(val)
+		if !ok {
+			return fmt.Sprintf("does not support the %s rule", name), false
+		}
+
+		switch {
+		case name == "len" && size != limit:
+			return fmt.Sprintf("must have length %s", param), false
+		case name == "min" && size < limit && isLength:
+			return fmt.Sprintf("must have at least %s elements", param), false
+		case name == "min" && size < limit:
+			return fmt.Sprintf("must be at least %s", param), false
+		case name == "max" && size > limit && isLength:
+			return fmt.Sprintf("must have at most %s elements", param), false
+		case name == "max" && size > limit:
+			return fmt.Sprintf("must be at most %s", param), false
+		}
+
+	case "enum":
+		values := strings.Split(param, "|")
+		actual := fmt.Sprint(val.Interface())
+		for _, value := range values {
+			if value == actual {
+				return "", true
+			}
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(values, ", ")), false
+
+	case "regex":
+		if val.Kind() != reflect.String {
+			return "does not support the regex rule", false
+		}
+
+		expr, err := compileRule(param)
+		if err != nil {
+			return fmt.Sprintf("has an invalid regex rule: %s", err), false
+		}
+
+		if !expr.MatchString(val.String()) {
+			return fmt.Sprintf("must match %s", param), false
+		}
+
+	default:
+		return fmt.Sprintf("has an unknown rule %q", name), false
+	}
+
+	return "", true
+}
+
+// It returns the number a min, max or len rule compares, and whether it is
+// a length rather than a value.
+func measure(val reflect.Value) (size float64, isLength bool, ok bool) {
+	switch val.Kind() {
+	case reflect.String:
+		return float64(len([]rune(val.String()))), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(val.Len()), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), false, true
+	}
+
+	return 0, false, false
+}
+
+var ruleExpressions sync.Map
+
+func compileRule(pattern string) (*regexp.Regexp, error) {
+	if expr, ok := ruleExpressions.Load(pattern); ok {
+		return expr.(*regexp.Regexp), nil
+	}
+
+	expr, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleExpressions.Store(pattern, expr)
+
+	return expr, nil
+}
@@ -0,0 +1,194 @@
+package yamgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const DefaultVersionKey = "__v"
+
+// ErrVersionConflict is matched by every *VersionConflictError.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflictError is returned when a versioned write did not match the
+// stored version of the document, which was modified in the meantime.
+type VersionConflictError struct {
+	Collection string
+	Version    int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict in %s: document is no longer at version %d", e.Collection, e.Version)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// WithVersioning makes the model keep a version number in the __v field.
+// Replaces and Save match on the version of the document and increment it,
+// updates increment it, and a stale write fails with ErrVersionConflict.
+func WithVersioning() ModelOption {
+	return WithVersionKey(DefaultVersionKey)
+}
+
+// WithVersionKey is like WithVersioning with a custom field name.
+func WithVersionKey(key string) ModelOption {
+	return func(mf *Model) {
+		mf.versionField = key
+	}
+}
+
+// It increments the version in an update document, unless the update sets
+// the version itself.
+func (mf *Model) versionUpdate(update interface{}) interface{} {
+	if mf.versionField == "" {
+		return update
+	}
+
+	versioned, ok := copyUpdate(update)
+	if !ok || updateSets(versioned, mf.versionField) {
+		return update
+	}
+
+	versioned["$inc"] = withField(versioned["$inc"], mf.versionField, 1)
+
+	return versioned
+}
+
+// It restricts filter to the given version of a document. Version 0 also
+// matches documents stored without a version, e.g. through omitempty.
+func (mf *Model) versionFilter(filter bson.M, version int64) bson.M {
+	condition := bson.M{mf.versionField: version}
+	if version == 0 {
+		condition = bson.M{mf.versionField: bson.M{"$in": bson.A{0, nil}}}
+	}
+
+	if len(filter) == 0 {
+		return condition
+	}
+
+	return bson.M{"$and": []bson.M{filter, condition}}
+}
+
+// It reads the version of a document and sets the next one. Struct values
+// are copied, so the returned document must be used in place of doc; the
+// restore function puts the previous version back after a failed write.
+func (mf *Model) bumpVersion(doc interface{}) (version int64, bumped interface{}, restore func(), ok bool) {
+	restore = func() {}
+
+	if mf.versionField == "" {
+		return 0, doc, restore, false
+	}
+
+	if m, isMap := doc.(bson.M); isMap {
+		previous, exists := m[mf.versionField]
+		if exists {
+			if version, ok = toInt64(previous); !ok {
+				return 0, doc, restore, false
+			}
+		}
+		m[mf.versionField] = version + 1
+		return version, m, func() {
+			if exists {
+				m[mf.versionField] = previous
+			} else {
+				delete(m, mf.versionField)
+			}
+		}, true
+	}
+
+	val := reflect.ValueOf(doc)
+	if val.Kind() != reflect.Ptr && val.Kind() != reflect.Struct {
+		return 0, doc, restore, false
+	}
+
+	if val.Kind() == reflect.Struct {
+		copied := reflect.New(val.Type())
+		copied.Elem().Set(val)
+		val = copied
+	}
+
+	structVal, err := documentStruct(val.Interface())
+	if err != nil {
+		return 0, doc, restore, false
+	}
+
+	field, found := fieldByBSONKey(structVal, mf.versionField)
+	if !found || !field.CanInt() {
+		return 0, doc, restore, false
+	}
+
+	version = field.Int()
+	field.SetInt(version + 1)
+
+	if reflect.ValueOf(doc).Kind() == reflect.Struct {
+		return version, val.Elem().Interface(), restore, true
+	}
+
+	return version, doc, func() { field.SetInt(version) }, true
+}
+
+// It tells a stale version from a missing document after a versioned write
+// matched nothing: the document still matches the filter without the version.
+func (mf *Model) versionConflict(filter bson.M, version int64) error {
+	exists, err := mf.exists(filter)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	return &VersionConflictError{Collection: mf.col.Name(), Version: version}
+}
+
+// It returns a copy of filter without the version condition, if it has one.
+func (mf *Model) withoutVersion(filter bson.M) (bson.M, int64, bool) {
+	value, ok := filter[mf.versionField]
+	if mf.versionField == "" || !ok {
+		return filter, 0, false
+	}
+
+	version, _ := toInt64(value)
+	stripped := make(bson.M, len(filter))
+	for key, v := range filter {
+		if key != mf.versionField {
+			stripped[key] = v
+		}
+	}
+
+	return stripped, version, true
+}
+
+// It checks whether an update that matched nothing ran into a stale version
+// given in its filter.
+func (mf *Model) checkVersion(filter bson.M, matched bool) error {
+	if matched {
+		return nil
+	}
+
+	stripped, version, ok := mf.withoutVersion(filter)
+	if !ok {
+		return nil
+	}
+
+	return mf.versionConflict(stripped, version)
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
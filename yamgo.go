@@ -2,17 +2,41 @@ package yamgo
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
-	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type Model struct {
-	col *mongo.Collection
+	col            *mongo.Collection
+	ctx            context.Context
+	client         *Client
+	timeouts       map[OperationKind]time.Duration
+	defaultTimeout time.Duration
+	timeout        time.Duration
+	createdField   string
+	updatedField   string
+	clock          func() time.Time
+	deletedField   string
+	deletedScope   deletedScope
+	// lookupDeleted maps populated collections to their soft delete field.
+	lookupDeleted map[string]string
+	versionField  string
+	validators    []ValidatorFunc
+
+	schema           interface{}
+	validationLevel  string
+	validationAction string
+	indexes          []Index
+	middleware       []Middleware
 }
 
 type Mongo struct {
@@ -21,9 +45,42 @@ type Mongo struct {
 	Err      error
 }
 
+// Client is an independent connection to a database. Several clients can be
+// used side by side to talk to different clusters or databases.
+type Client struct {
+	client   *mongo.Client
+	Database *mongo.Database
+	// logger holds a loggerValue, replaced by SetLogger while the command
+	// monitor reads it.
+	logger        atomic.Value
+	slowThreshold time.Duration
+	health        *healthMonitor
+
+	mu         sync.RWMutex
+	middleware []Middleware
+}
+
+// ConnectionParams configures a client. Zero values leave the driver defaults,
+// or the values given in the connection string, untouched.
 type ConnectionParams struct {
-	ConnectionUrl string
-	DbName        string
+	ConnectionUrl          string
+	DbName                 string
+	AppName                string
+	Username               string
+	Password               string
+	AuthSource             string
+	MaxPoolSize            uint64
+	MinPoolSize            uint64
+	TLSConfig              *tls.Config
+	ReadPreference         *readpref.ReadPref
+	WriteConcern           *writeconcern.WriteConcern
+	RetryWrites            *bool
+	RetryReads             *bool
+	ServerSelectionTimeout time.Duration
+	ConnectTimeout         time.Duration
+	Logger                 Logger
+	LogLevel               LogLevel
+	SlowOperationThreshold time.Duration
 }
 
 const (
@@ -34,39 +91,160 @@ const (
 
 var _mongo Mongo
 
-// It connects to the database.
-func Connect(params ConnectionParams) {
+var _client *Client
+
+// It creates a new client connected to the database.
+func NewClient(params ConnectionParams) (*Client, error) {
 	connectionURL := params.ConnectionUrl
 	dbName := params.DbName
 
 	if connectionURL == "" || dbName == "" {
-		panic(errors.New("cannot start db, missing connection parameters"))
-	}
-
-	if _mongo.client == nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		_mongo.client, _mongo.Err = mongo.Connect(ctx, options.Client().ApplyURI(connectionURL))
-		if _mongo.Err == nil {
-			_mongo.Database = _mongo.client.Database(dbName)
-			fmt.Printf("Successfully connected to db! (%s)\n", dbName)
-		} else {
-			panic(_mongo.Err)
+		return nil, errors.New("cannot start db, missing connection parameters")
+	}
+
+	clientOptions, err := params.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		slowThreshold: params.SlowOperationThreshold,
+		health:        &healthMonitor{},
+	}
+	c.logger.Store(loggerValue{newLeveledLogger(params.Logger, params.LogLevel)})
+	clientOptions.SetMonitor(c.commandMonitor())
+	clientOptions.SetServerMonitor(c.health.serverMonitor())
+	clientOptions.SetPoolMonitor(c.health.poolMonitor())
+
+	ctx, cancel := context.WithTimeout(context.Background(), LongTimeout*time.Second)
+	defer cancel()
+
+	c.client, err = mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		c.currentLogger().Log(ctx, LogLevelError, "cannot connect to db", "db", dbName, "error", err)
+		return nil, err
+	}
+
+	c.Database = c.client.Database(dbName)
+	c.currentLogger().Log(ctx, LogLevelInfo, "connected to db", "db", dbName)
+
+	return c, nil
+}
+
+// It maps the connection parameters onto the driver client options.
+func (params ConnectionParams) clientOptions() (*options.ClientOptions, error) {
+	clientOptions := options.Client().ApplyURI(params.ConnectionUrl)
+
+	if params.AppName != "" {
+		clientOptions.SetAppName(params.AppName)
+	}
+
+	if params.Username != "" {
+		clientOptions.SetAuth(options.Credential{
+			Username:   params.Username,
+			Password:   params.Password,
+			AuthSource: params.AuthSource,
+		})
+	} else if params.AuthSource != "" {
+		if clientOptions.Auth == nil {
+			return nil, errors.New("cannot set auth source without credentials")
 		}
+		clientOptions.Auth.AuthSource = params.AuthSource
+	}
+
+	if params.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(params.MaxPoolSize)
+	}
+
+	if params.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(params.MinPoolSize)
+	}
+
+	if params.TLSConfig != nil {
+		clientOptions.SetTLSConfig(params.TLSConfig)
+	}
+
+	if params.ReadPreference != nil {
+		clientOptions.SetReadPreference(params.ReadPreference)
 	}
+
+	if params.WriteConcern != nil {
+		clientOptions.SetWriteConcern(params.WriteConcern)
+	}
+
+	if params.RetryWrites != nil {
+		clientOptions.SetRetryWrites(*params.RetryWrites)
+	}
+
+	if params.RetryReads != nil {
+		clientOptions.SetRetryReads(*params.RetryReads)
+	}
+
+	if params.ServerSelectionTimeout > 0 {
+		clientOptions.SetServerSelectionTimeout(params.ServerSelectionTimeout)
+	}
+
+	if params.ConnectTimeout > 0 {
+		clientOptions.SetConnectTimeout(params.ConnectTimeout)
+	}
+
+	return clientOptions, clientOptions.Validate()
+}
+
+func (c *Client) Disconnect() error {
+	ctx := context.TODO()
+	c.currentLogger().Log(ctx, LogLevelInfo, "disconnecting from db", "db", c.Database.Name())
+
+	err := c.client.Disconnect(ctx)
+	if err != nil {
+		c.currentLogger().Log(ctx, LogLevelError, "cannot disconnect from db", "db", c.Database.Name(), "error", err)
+	}
+
+	return err
+}
+
+func (c *Client) GetCollection(collectionName string) *mongo.Collection {
+	return c.Database.Collection(collectionName)
+}
+
+func (c *Client) NewModel(collectionName string, opts ...ModelOption) Model {
+	model := Model{col: c.GetCollection(collectionName), client: c}
+
+	for _, opt := range opts {
+		opt(&model)
+	}
+
+	return model
+}
+
+// It connects the default client used by the package level functions.
+func Connect(params ConnectionParams) error {
+	if _client == nil {
+		_client, _mongo.Err = NewClient(params)
+		if _mongo.Err != nil {
+			return _mongo.Err
+		}
+		_mongo.client = _client.client
+		_mongo.Database = _client.Database
+	}
+
+	return nil
 }
 
 func Disconnect() error {
-	fmt.Println("Disconnecting from DB")
-	return _mongo.client.Disconnect(context.TODO())
+	return _client.Disconnect()
 }
 
 func GetDB() Mongo {
 	return _mongo
 }
 
+func GetClient() *Client {
+	return _client
+}
+
 func GetCollection(collectionName string) *mongo.Collection {
-	return _mongo.Database.Collection(collectionName)
+	return _client.GetCollection(collectionName)
 }
 
 func ToObjectID(hex string) primitive.ObjectID {
@@ -79,6 +257,6 @@ func ToObjectID(hex string) primitive.ObjectID {
 	return oId
 }
 
-func NewModel(collectionName string) Model {
-	return Model{col: GetCollection(collectionName)}
+func NewModel(collectionName string, opts ...ModelOption) Model {
+	return _client.NewModel(collectionName, opts...)
 }